@@ -1,19 +1,28 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings"
 
+	"github.com/MaiMarincic/bruh/internal/shellhistory"
+	"github.com/MaiMarincic/bruh/internal/varsuggest"
+	"github.com/MaiMarincic/bruh/pkg/cheatsheet"
 	"github.com/spf13/cobra"
 )
 
 var addcheatCmd = &cobra.Command{
 	Use:   "addcheat [additional instructions]",
 	Short: "Add the last command from history to a navi cheat sheet",
-	Long:  "Retrieves the last command from shell history and asks Claude Code to add it to an appropriate navi cheat sheet. You can provide additional instructions as arguments.",
+	Long: `Retrieves the last command from shell history and asks Claude Code to add it to an appropriate navi cheat sheet. You can provide additional instructions as arguments.
+
+Before involving Claude Code, bruh runs a local heuristic pass (internal/varsuggest) over the command to propose <variable> placeholders (git refs, docker IDs, paths, ports, URLs, ...) and walks you through accepting, renaming, or skipping each one in an interactive prompt. If that pass finds at least one suggestion, the parameterized command is written with the local cheat sheet parser (pkg/cheatsheet) instead of calling out to Claude Code.
+
+Pass --llm to always escalate to Claude Code regardless of what the local pass finds, or --no-llm to always stay local, writing the command unparameterized if no suggestions are found.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cheatDir, _ := cmd.Flags().GetString("cheat-directory")
 
@@ -26,7 +35,7 @@ var addcheatCmd = &cobra.Command{
 			return fmt.Errorf("cheat directory does not exist: %s", absCheatDir)
 		}
 
-		lastCommand, err := getLastCommand()
+		lastCommand, err := getLastCommand(cmd)
 		if err != nil {
 			return fmt.Errorf("failed to get last command: %v", err)
 		}
@@ -41,53 +50,197 @@ var addcheatCmd = &cobra.Command{
 			additionalInstructions = strings.Join(args, " ")
 		}
 
-		if err := sendToClaudeCode(lastCommand, absCheatDir, additionalInstructions); err != nil {
-			return fmt.Errorf("failed to send to Claude Code: %v", err)
+		noLLM, _ := cmd.Flags().GetBool("no-llm")
+		forceLLM, _ := cmd.Flags().GetBool("llm")
+		interactive, _ := cmd.Flags().GetBool("interactive")
+
+		suggestions := varsuggest.Analyze(lastCommand)
+		if !noLLM && !interactive && (forceLLM || len(suggestions) == 0) {
+			if err := sendToClaudeCode(lastCommand, absCheatDir, additionalInstructions); err != nil {
+				return fmt.Errorf("failed to send to Claude Code: %v", err)
+			}
+
+			fmt.Printf("Sent command to Claude Code for addition to cheat sheets\n")
+			return nil
+		}
+
+		if len(suggestions) > 0 {
+			suggestions = varsuggest.Review(suggestions, bufio.NewReader(os.Stdin), os.Stdout)
 		}
 
-		fmt.Printf("Sent command to Claude Code for addition to cheat sheets\n")
+		var sheetPath string
+		if interactive {
+			sheetPath, err = addEntryInteractively(lastCommand, absCheatDir, suggestions)
+		} else {
+			sheetPath, err = addEntryLocally(lastCommand, absCheatDir, suggestions)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to add command to cheat sheet: %v", err)
+		}
+		fmt.Printf("Added command to %s\n", sheetPath)
 		return nil
 	},
 }
 
+// defaultCheatDir is the cheat directory used when -d/--cheat-directory
+// isn't passed, shared by addcheatCmd and syncCmd.
+func defaultCheatDir() string {
+	return filepath.Join(os.Getenv("HOME"), "Thoth", "04-Resources", "navi-cheatsheets")
+}
+
 func init() {
-	defaultDir := filepath.Join(os.Getenv("HOME"), "Thoth", "04-Resources", "navi-cheatsheets")
-	addcheatCmd.Flags().StringP("cheat-directory", "d", defaultDir, "Directory containing navi cheat sheets")
+	addcheatCmd.Flags().StringP("cheat-directory", "d", defaultCheatDir(), "Directory containing navi cheat sheets")
+	addcheatCmd.Flags().Bool("no-llm", false, "Add the command deterministically using the local cheat sheet parser, without calling Claude Code")
+	addcheatCmd.Flags().Bool("llm", false, "Always escalate to Claude Code, even if the local variable-detection pass finds suggestions")
+	addcheatCmd.Flags().BoolP("interactive", "i", false, "Interactively pick the destination sheet and tag set instead of delegating file selection to Claude Code")
+	addcheatCmd.Flags().String("shell", "", "Shell history format to read: zsh, bash, or fish (default: auto-detect from $SHELL)")
+	addcheatCmd.Flags().Int("nth", 1, "Which command to grab from history, counting back from the most recent (1 = last)")
+	addcheatCmd.Flags().String("filter", `^(ls|cd|pwd|bruh)\b`, "Regex of commands to skip when selecting from history")
 }
 
-func getLastCommand() (string, error) {
-	histCmd := exec.Command("zsh", "-c", "tail -2 ~/.zsh_history | head -1")
-	output, err := histCmd.Output()
+// addEntryLocally adds lastCommand to whichever existing sheet in cheatDir
+// shares the most tags with the command's guessed tags, creating a new
+// sheet named after the primary tag if none exists yet. Any accepted
+// suggestions are applied to parameterize the command and recorded as the
+// entry's variables. It returns the path of the sheet that was written.
+func addEntryLocally(lastCommand, cheatDir string, suggestions []varsuggest.Suggestion) (string, error) {
+	tags := guessTags(lastCommand)
+
+	sheets, err := cheatsheet.Load(cheatDir)
 	if err != nil {
 		return "", err
 	}
 
-	result := strings.TrimSpace(string(output))
-	if strings.Contains(result, ";") {
-		parts := strings.SplitN(result, ";", 2)
-		if len(parts) > 1 {
-			result = parts[1]
+	sheet := cheatsheet.PickSheet(sheets, tags)
+	if sheet == nil {
+		primaryTag := "misc"
+		if len(tags) > 0 {
+			primaryTag = tags[0]
 		}
+		sheet = cheatsheet.New(filepath.Join(cheatDir, primaryTag+".cheat"))
+	}
+
+	command := lastCommand
+	var variables []cheatsheet.Variable
+	if len(suggestions) > 0 {
+		command = varsuggest.Apply(lastCommand, suggestions)
+		for _, s := range suggestions {
+			variables = append(variables, s.Variable)
+		}
+	}
+
+	sheet.AddEntry(cheatsheet.Entry{
+		Tags:        tags,
+		Description: lastCommand,
+		Command:     command,
+		Variables:   variables,
+	})
+
+	if err := sheet.Save(); err != nil {
+		return "", err
 	}
 
-	return result, nil
+	return sheet.Path, nil
 }
 
-func sendToClaudeCode(command, cheatDir, additionalInstructions string) error {
-	prompt := fmt.Sprintf(`Add this command to the appropriate navi cheat sheet in the directory %s:
+// addEntryInteractively behaves like addEntryLocally, but lets the user
+// pick the destination sheet and tag set via pickSheetInteractive instead
+// of choosing the best-tag-overlap sheet automatically. If the chosen tag
+// set already defines variables the suggestions name, the entry reuses
+// them via an "@" extension line rather than redefining them.
+func addEntryInteractively(lastCommand, cheatDir string, suggestions []varsuggest.Suggestion) (string, error) {
+	tags := guessTags(lastCommand)
 
-Command: %s
+	sheets, err := cheatsheet.Load(cheatDir)
+	if err != nil {
+		return "", err
+	}
 
-Instructions:
-1. Find the most appropriate existing cheat sheet file (.cheat) in the directory
-2. If no appropriate file exists, create a new one with a suitable name
-3. Add the command with proper navi syntax, including:
-   - A descriptive comment (starting with #)
-   - The command itself (starting with $)
-   - Any relevant tags or variables if the command has parameters
-4. Ensure the formatting follows navi conventions
-Here is the exact syntax readme:
-# The syntax of a Navi cheatsheet
+	sheet, extends, remaining, err := pickSheetInteractive(sheets, cheatDir, tags, suggestions)
+	if err != nil {
+		return "", err
+	}
+
+	command := lastCommand
+	var variables []cheatsheet.Variable
+	if len(suggestions) > 0 {
+		command = varsuggest.Apply(lastCommand, suggestions)
+		for _, s := range remaining {
+			variables = append(variables, s.Variable)
+		}
+	}
+
+	sheet.AddEntry(cheatsheet.Entry{
+		Tags:        tags,
+		Extends:     extends,
+		Description: lastCommand,
+		Command:     command,
+		Variables:   variables,
+	})
+
+	if err := sheet.Save(); err != nil {
+		return "", err
+	}
+
+	return sheet.Path, nil
+}
+
+// guessTags derives navi tags from a command's leading words, e.g.
+// "git checkout main" -> ["git", "checkout"].
+func guessTags(command string) []string {
+	fields := strings.Fields(command)
+	var tags []string
+	for i, f := range fields {
+		if i >= 2 {
+			break
+		}
+		if strings.HasPrefix(f, "-") {
+			break
+		}
+		tags = append(tags, f)
+	}
+	return tags
+}
+
+func getLastCommand(cmd *cobra.Command) (string, error) {
+	shell, _ := cmd.Flags().GetString("shell")
+	if shell == "" {
+		shell = shellhistory.Detect()
+	}
+	if shell == "" {
+		shell = "zsh"
+	}
+
+	nth, _ := cmd.Flags().GetInt("nth")
+
+	filterPattern, _ := cmd.Flags().GetString("filter")
+	var exclude *regexp.Regexp
+	if filterPattern != "" {
+		re, err := regexp.Compile(filterPattern)
+		if err != nil {
+			return "", fmt.Errorf("invalid --filter pattern: %v", err)
+		}
+		exclude = re
+	}
+
+	entries, err := shellhistory.Read(shell)
+	if err != nil {
+		return "", err
+	}
+
+	entry, err := shellhistory.Nth(entries, nth, exclude)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(entry.Command), nil
+}
+
+// naviSyntaxReadme is the upstream navi README section documenting
+// cheatsheet syntax, embedded verbatim for the prompt below. It's kept out
+// of the fmt.Sprintf format string: the readme's own "%" tag-line markers
+// (e.g. "% git, code") would otherwise be parsed as Sprintf verbs.
+const naviSyntaxReadme = `# The syntax of a Navi cheatsheet
 
 <!-- TOC -->
 * [The syntax of a Navi cheatsheet](#the-syntax-of-a-navi-cheatsheet)
@@ -328,10 +481,26 @@ navibestmatch() {
 
 alias el="navibestmatch 'This is one command'"
 alias ef="navibestmatch 'This is another command'"
+`
 
+func sendToClaudeCode(command, cheatDir, additionalInstructions string) error {
+	prompt := fmt.Sprintf(`Add this command to the appropriate navi cheat sheet in the directory %s:
+
+Command: %s
+
+Instructions:
+1. Find the most appropriate existing cheat sheet file (.cheat) in the directory
+2. If no appropriate file exists, create a new one with a suitable name
+3. Add the command with proper navi syntax, including:
+   - A descriptive comment (starting with #)
+   - The command itself (starting with $)
+   - Any relevant tags or variables if the command has parameters
+4. Ensure the formatting follows navi conventions
+Here is the exact syntax readme:
+`, cheatDir, command) + naviSyntaxReadme + `
 Make the addition concise and useful for future reference.
 
-Do everything autonomously without asking for confirmation. Allow all file operations and tool usage.`, cheatDir, command)
+Do everything autonomously without asking for confirmation. Allow all file operations and tool usage.`
 
 	// Add additional instructions if provided
 	if additionalInstructions != "" {
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/MaiMarincic/bruh/internal/addcheatstate"
+	"github.com/MaiMarincic/bruh/internal/varsuggest"
+	"github.com/MaiMarincic/bruh/pkg/cheatsheet"
+)
+
+// sheetChoice is one selectable destination in the interactive picker: an
+// existing sheet's tag block, or the option to create a new sheet.
+type sheetChoice struct {
+	label string
+	sheet *cheatsheet.Sheet // nil means "create a new sheet"
+	tags  []string
+}
+
+// pickSheetInteractive lets the user choose which sheet and tag block a new
+// entry should join, defaulting to whatever cheatDir was last chosen for.
+// It persists the choice afterward. If suggestions is non-empty and the
+// chosen tag block already defines all of them, the matching suggestions
+// are dropped and their tag block is returned as extends so the new entry
+// can reuse them via an "@" extension line instead of redefining them.
+func pickSheetInteractive(sheets []*cheatsheet.Sheet, cheatDir string, newTags []string, suggestions []varsuggest.Suggestion) (*cheatsheet.Sheet, []string, []varsuggest.Suggestion, error) {
+	state, err := addcheatstate.Load()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	choices := candidateChoices(sheets, newTags)
+
+	labels := make([]string, len(choices))
+	defaultIdx := 0
+	last := state.Last(cheatDir)
+	for i, c := range choices {
+		labels[i] = c.label
+		if c.sheet != nil && c.sheet.Path == last {
+			defaultIdx = i
+		}
+	}
+
+	picked, err := pickFromList(labels, defaultIdx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	choice := choices[picked]
+
+	sheet := choice.sheet
+	if sheet == nil {
+		primaryTag := "misc"
+		if len(newTags) > 0 {
+			primaryTag = newTags[0]
+		}
+		sheet = cheatsheet.New(filepath.Join(cheatDir, primaryTag+".cheat"))
+	}
+
+	if err := state.SetLast(cheatDir, sheet.Path); err != nil {
+		return nil, nil, nil, err
+	}
+
+	extends, remaining := reuseExtension(choice.sheet, choice.tags, suggestions)
+	return sheet, extends, remaining, nil
+}
+
+// candidateChoices lists every distinct tag block across sheets, plus a
+// leading option to create a new sheet for newTags.
+func candidateChoices(sheets []*cheatsheet.Sheet, newTags []string) []sheetChoice {
+	choices := []sheetChoice{
+		{label: fmt.Sprintf("+ new sheet (%s)", strings.Join(newTags, ", "))},
+	}
+
+	for _, sheet := range sheets {
+		var prevTags []string
+		for _, e := range sheet.Entries {
+			if sameTagSet(e.Tags, prevTags) {
+				continue
+			}
+			prevTags = e.Tags
+			choices = append(choices, sheetChoice{
+				label: fmt.Sprintf("%s: %s", filepath.Base(sheet.Path), strings.Join(e.Tags, ", ")),
+				sheet: sheet,
+				tags:  e.Tags,
+			})
+		}
+	}
+
+	return choices
+}
+
+// reuseExtension reports whether the tag block chosen from sheet already
+// defines every variable suggestions names. If so, it returns that tag
+// block as extends and drops those suggestions from remaining, so the
+// caller's entry can extend the existing definitions (via an "@" line)
+// instead of redefining them. Otherwise it returns suggestions unchanged.
+func reuseExtension(sheet *cheatsheet.Sheet, tags []string, suggestions []varsuggest.Suggestion) (extends []string, remaining []varsuggest.Suggestion) {
+	if sheet == nil || len(tags) == 0 || len(suggestions) == 0 {
+		return nil, suggestions
+	}
+
+	defined := map[string]bool{}
+	for _, e := range sheet.Entries {
+		if !sameTagSet(e.Tags, tags) {
+			continue
+		}
+		for _, v := range e.Variables {
+			defined[v.Name] = true
+		}
+	}
+
+	for _, s := range suggestions {
+		if !defined[s.Name] {
+			return nil, suggestions
+		}
+	}
+
+	return tags, nil
+}
+
+func sameTagSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// pickFromList shows labels to the user and returns the chosen index,
+// preferring fzf when it's on PATH and falling back to a plain numbered
+// prompt otherwise.
+func pickFromList(labels []string, defaultIdx int) (int, error) {
+	if len(labels) == 0 {
+		return 0, fmt.Errorf("nothing to pick from")
+	}
+
+	if _, err := exec.LookPath("fzf"); err == nil {
+		return fzfPick(labels, defaultIdx)
+	}
+
+	return textPick(labels, defaultIdx)
+}
+
+func fzfPick(labels []string, defaultIdx int) (int, error) {
+	fzfCmd := exec.Command("fzf", "--query", labels[defaultIdx])
+	fzfCmd.Stdin = strings.NewReader(strings.Join(labels, "\n"))
+	fzfCmd.Stderr = os.Stderr
+
+	out, err := fzfCmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("fzf selection failed: %v", err)
+	}
+
+	picked := strings.TrimSpace(string(out))
+	for i, l := range labels {
+		if l == picked {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("fzf returned an unrecognized choice: %q", picked)
+}
+
+func textPick(labels []string, defaultIdx int) (int, error) {
+	for i, l := range labels {
+		fmt.Printf("  [%d] %s\n", i+1, l)
+	}
+	fmt.Printf("Pick a destination (default: %d): ", defaultIdx+1)
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return defaultIdx, nil
+	}
+
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return defaultIdx, nil
+	}
+
+	n, err := strconv.Atoi(line)
+	if err != nil || n < 1 || n > len(labels) {
+		return 0, fmt.Errorf("invalid choice: %q", line)
+	}
+
+	return n - 1, nil
+}
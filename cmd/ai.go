@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MaiMarincic/bruh/internal/ai"
+	"github.com/spf13/cobra"
+)
+
+var aiCmd = &cobra.Command{
+	Use:   "ai",
+	Short: "Inspect and manage bruh's configured AI provider",
+}
+
+var aiDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Verify the configured AI provider is reachable",
+	RunE:  runAIDoctor,
+}
+
+func init() {
+	aiCmd.AddCommand(aiDoctorCmd)
+	rootCmd.AddCommand(aiCmd)
+}
+
+func runAIDoctor(cmd *cobra.Command, args []string) error {
+	checker, ok := App.AI.(ai.DoctorChecker)
+	if !ok {
+		return fmt.Errorf("provider %s does not support a reachability check", App.AI.Name())
+	}
+
+	fmt.Printf("Checking %s...\n", App.AI.Name())
+	if err := checker.Doctor(context.Background()); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s is reachable.\n", App.AI.Name())
+	return nil
+}
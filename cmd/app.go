@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/MaiMarincic/bruh/config"
+	"github.com/MaiMarincic/bruh/internal/ai"
+	"github.com/MaiMarincic/bruh/pkg/git"
+)
+
+// AppContext holds dependencies shared across cobra commands, constructed
+// once as the package-level App below. Swapping App.Git for a *git.FakeClient in tests makes
+// runE handlers table-testable without spawning a real git process.
+type AppContext struct {
+	Git git.Client
+	AI  ai.Provider
+}
+
+var App = &AppContext{
+	Git: git.NewClient(),
+	AI:  newAIProvider(),
+}
+
+// newAIProvider builds the ai.Provider selected by the ai.provider config
+// key, reading API keys from the environment rather than the config file.
+func newAIProvider() ai.Provider {
+	cfg := config.Get().AI
+
+	switch cfg.Provider {
+	case "openai":
+		return ai.NewOpenAI(os.Getenv("OPENAI_API_KEY"), cfg.Model)
+	case "openai-compatible":
+		return ai.NewOpenAICompatible(cfg.BaseURL, os.Getenv("BRUH_AI_API_KEY"), cfg.Model)
+	default:
+		return ai.NewClaudeCLI(func() bool { return Verbose })
+	}
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MaiMarincic/bruh/pkg/git"
+)
+
+func TestCreateWorktree(t *testing.T) {
+	fake := &git.FakeClient{
+		RepoRootFn: func() (string, error) { return "/home/user/bruh", nil },
+	}
+	prevGit := App.Git
+	App.Git = fake
+	defer func() { App.Git = prevGit }()
+
+	path, err := createWorktree(BranchRuntime{BranchName: "feature-worktree", FromBranch: "main"})
+	if err != nil {
+		t.Fatalf("createWorktree returned error: %v", err)
+	}
+
+	want := "/home/user/bruh-feature-worktree"
+	if path != want {
+		t.Errorf("createWorktree path = %q, want %q", path, want)
+	}
+
+	if len(fake.Calls) != 2 || fake.Calls[0] != "RepoRoot" || fake.Calls[1] != "AddWorktree" {
+		t.Errorf("unexpected call sequence: %v", fake.Calls)
+	}
+}
+
+func TestCreateWorktree_PropagatesAddWorktreeError(t *testing.T) {
+	fake := &git.FakeClient{
+		RepoRootFn: func() (string, error) { return "/home/user/bruh", nil },
+		AddWorktreeFn: func(path, newBranch, from string) error {
+			return errors.New("boom")
+		},
+	}
+	prevGit := App.Git
+	App.Git = fake
+	defer func() { App.Git = prevGit }()
+
+	if _, err := createWorktree(BranchRuntime{BranchName: "feature-worktree", FromBranch: "main"}); err == nil {
+		t.Fatal("expected createWorktree to propagate AddWorktree error")
+	}
+}
@@ -1,13 +1,17 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
-	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/MaiMarincic/bruh/config"
+	"github.com/MaiMarincic/bruh/internal/ai"
+	"github.com/MaiMarincic/bruh/internal/ai/prompts"
+	"github.com/MaiMarincic/bruh/internal/errs"
+	"github.com/MaiMarincic/bruh/internal/gitcmd"
 	"github.com/briandowns/spinner"
 	"github.com/spf13/cobra"
 )
@@ -15,15 +19,16 @@ import (
 var commitCmd = &cobra.Command{
 	Use:   "commit [message]",
 	Short: "Commit staged changes with an AI-generated commit message and pre-commit cleanup",
-	Long: `Commit staged changes using Claude to generate a well-formed commit message.
-If no message is provided, Claude will analyze the changes and create an appropriate commit message.
+	Long: `Commit staged changes using the configured AI provider (see "ai.provider" in config, default claude-cli) to generate a well-formed commit message.
+If no message is provided, the provider will analyze the changes and create an appropriate commit message.
 By default, runs pre-commit cleanup to fix any issues before committing.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if err := validateGitRepo(); err != nil {
-			return fmt.Errorf("not in a git repository")
+		if !App.Git.IsRepo() {
+			return errs.NewErrorWithHint("check repository", fmt.Errorf("not in a git repository"),
+				"Run `git init` to create one, or `cd` into an existing git repository.")
 		}
 
-		if !hasStagedChanges() {
+		if !App.Git.HasStagedChanges() {
 			return fmt.Errorf("no staged changes to commit")
 		}
 
@@ -31,7 +36,7 @@ By default, runs pre-commit cleanup to fix any issues before committing.`,
 
 		if !cmd.Flags().Changed("cleanup-pre-commit") {
 			cfg := config.Get()
-			repoName, err := getRepoName()
+			repoName, err := App.Git.RepoName()
 			if err == nil {
 				for _, project := range cfg.CleanupPreCommit {
 					if project == repoName {
@@ -44,7 +49,8 @@ By default, runs pre-commit cleanup to fix any issues before committing.`,
 
 		if cleanupPreCommit {
 			if err := runPreCommitCleanup(); err != nil {
-				return fmt.Errorf("pre-commit cleanup failed: %v", err)
+				return errs.NewErrorWithHint("pre-commit cleanup", err,
+					"Run `pre-commit run --all-files` yourself to see the full output, or install pre-commit from https://pre-commit.com.")
 			}
 		}
 
@@ -53,7 +59,7 @@ By default, runs pre-commit cleanup to fix any issues before committing.`,
 			commitMessage = strings.Join(args, " ")
 		} else {
 			s := spinner.New(spinner.CharSets[11], 100*time.Millisecond)
-			s.Suffix = " Generating commit message with Claude..."
+			s.Suffix = fmt.Sprintf(" Generating commit message with %s...", App.AI.Name())
 			s.Start()
 
 			message, err := generateCommitMessage()
@@ -65,7 +71,7 @@ By default, runs pre-commit cleanup to fix any issues before committing.`,
 			commitMessage = message
 		}
 
-		if err := performCommit(commitMessage); err != nil {
+		if err := App.Git.Commit(commitMessage, true); err != nil {
 			return fmt.Errorf("failed to commit: %v", err)
 		}
 
@@ -80,12 +86,6 @@ func init() {
 	rootCmd.AddCommand(commitCmd)
 }
 
-func hasStagedChanges() bool {
-	cmd := exec.Command("git", "diff", "--cached", "--exit-code")
-	err := cmd.Run()
-	return err != nil
-}
-
 func runPreCommitCleanup() error {
 	maxAttempts := 5
 
@@ -106,7 +106,7 @@ func runPreCommitCleanup() error {
 		fmt.Printf("Pre-commit issues found (attempt %d/%d):\n%s\n", attempt, maxAttempts, string(output))
 
 		s = spinner.New(spinner.CharSets[11], 100*time.Millisecond)
-		s.Suffix = " Fixing pre-commit issues with Claude..."
+		s.Suffix = fmt.Sprintf(" Fixing pre-commit issues with %s...", App.AI.Name())
 		s.Start()
 
 		if err := fixPreCommitIssues(string(output)); err != nil {
@@ -124,77 +124,72 @@ func runPreCommitCleanup() error {
 	return fmt.Errorf("failed to fix pre-commit issues after %d attempts", maxAttempts)
 }
 
-func fixPreCommitIssues(preCommitOutput string) error {
-	prompt := fmt.Sprintf(`Fix the following pre-commit issues in the current directory:
-
-%s
-
-Please analyze the errors and fix all the issues automatically. Only fix the issues, don't explain what you're doing.`, preCommitOutput)
+var fixPreCommitTools = []ai.Tool{
+	{Name: "Bash(*)"},
+	{Name: "Read(*)"},
+	{Name: "Edit(*)"},
+	{Name: "Glob(*)"},
+	{Name: "Grep(*)"},
+	{Name: "MultiEdit(*)"},
+}
 
-	claudeCmd := exec.Command("claude", "--print", "--dangerously-skip-permissions", "--allowedTools", "Bash(*),Read(*),Edit(*),Glob(*),Grep(*),MultiEdit(*)", "--", prompt)
-	claudeCmd.Stdin = strings.NewReader("")
-	output, err := claudeCmd.CombinedOutput()
+func fixPreCommitIssues(preCommitOutput string) error {
+	prompt, err := prompts.Render(repoRootOrEmpty(), "fix-pre-commit", prompts.FixPreCommitData{
+		Output: preCommitOutput,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to fix issues with Claude: %v\nOutput: %s", err, string(output))
+		return err
 	}
 
-	return nil
+	_, err = App.AI.Agent(context.Background(), prompt, fixPreCommitTools)
+	return err
 }
 
 func generateCommitMessage() (string, error) {
-	statusCmd := exec.Command("git", "status", "--porcelain")
-	statusOutput, err := statusCmd.Output()
+	statusOutput, _, err := gitcmd.New(context.Background(), "status").
+		AddArguments("--porcelain").
+		RunStdString(nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to get git status: %v", err)
 	}
 
-	diffCmd := exec.Command("git", "diff", "--cached", "--name-status")
-	diffOutput, err := diffCmd.Output()
+	diffOutput, _, err := gitcmd.New(context.Background(), "diff").
+		AddArguments("--cached", "--name-status").
+		RunStdString(nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to get git diff: %v", err)
 	}
 
-	prompt := fmt.Sprintf(`Based on the following git changes, generate a concise, short, well-formed commit message following conventional commit format:
-
-Git Status:
-%s
-
-Changed Files:
-%s
-
-Do not mention anything in the likes of written by AI.
-Please provide only the commit message without any additional text or explanation.`,
-		string(statusOutput), string(diffOutput))
+	prompt, err := prompts.Render(repoRootOrEmpty(), "commit", prompts.CommitData{
+		Status: statusOutput,
+		Diff:   diffOutput,
+	})
+	if err != nil {
+		return "", err
+	}
 
-	claudeCmd := exec.Command("claude", "--print", "--allowedTools", "Bash(git:*)", "--", prompt)
-	output, err := claudeCmd.Output()
+	message, err := App.AI.Generate(context.Background(), prompt, ai.GenerateOpts{AllowedTools: "Bash(git:*)"})
 	if err != nil {
-		return "", fmt.Errorf("failed to generate commit message with Claude: %v", err)
+		return "", err
 	}
 
-	message := strings.TrimSpace(string(output))
 	if message == "" {
-		return "", fmt.Errorf("Claude generated empty commit message")
+		return "", errs.NewErrorWithHint(fmt.Sprintf("generate commit message with %s", App.AI.Name()),
+			fmt.Errorf("provider generated an empty commit message"),
+			"Check that the configured AI provider is reachable with `bruh ai doctor`.")
 	}
 
 	return message, nil
 }
 
-func performCommit(message string) error {
-	cmd := exec.Command("git", "commit", "-m", message, "--no-verify")
-	output, err := cmd.CombinedOutput()
+// repoRootOrEmpty returns the repository root for resolving .bruh/prompts
+// overrides, or "" if it can't be determined, in which case prompts.Render
+// falls back to its built-in defaults.
+func repoRootOrEmpty() string {
+	root, err := App.Git.RepoRoot()
 	if err != nil {
-		return fmt.Errorf("git commit failed: %s", output)
+		return ""
 	}
-	return nil
+	return root
 }
 
-func getRepoName() (string, error) {
-	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
-	output, err := cmd.Output()
-	if err != nil {
-		return "", fmt.Errorf("failed to get repository root: %v", err)
-	}
-	repoRoot := strings.TrimSpace(string(output))
-	return filepath.Base(repoRoot), nil
-}
@@ -1,11 +1,17 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"os/exec"
 	"strings"
 	"time"
 
+	"github.com/MaiMarincic/bruh/internal/ai"
+	"github.com/MaiMarincic/bruh/internal/ai/prompts"
+	"github.com/MaiMarincic/bruh/internal/errs"
+	"github.com/MaiMarincic/bruh/internal/gitcmd"
 	"github.com/briandowns/spinner"
 	"github.com/spf13/cobra"
 )
@@ -13,17 +19,18 @@ import (
 var prCmd = &cobra.Command{
 	Use:   "pr create",
 	Short: "Create a pull request with AI-generated summary and test criteria",
-	Long: `Create a pull request using GitHub CLI with Claude-generated summary and testing criteria.
-Claude will analyze the changes between the current branch and the base branch to create
-a comprehensive PR description.`,
+	Long: `Create a pull request using GitHub CLI with an AI-generated summary and testing criteria.
+The configured AI provider (see "ai.provider" in config) will analyze the changes between the
+current branch and the base branch to create a comprehensive PR description.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Validate git repository
-		if err := validateGitRepo(); err != nil {
-			return fmt.Errorf("not in a git repository")
+		if !App.Git.IsRepo() {
+			return errs.NewErrorWithHint("check repository", fmt.Errorf("not in a git repository"),
+				"Run `git init` to create one, or `cd` into an existing git repository.")
 		}
 
 		// Check if we're on a branch other than main/master
-		currentBranch, err := getCurrentBranch()
+		currentBranch, err := App.Git.CurrentBranch()
 		if err != nil {
 			return fmt.Errorf("failed to get current branch: %v", err)
 		}
@@ -39,7 +46,7 @@ a comprehensive PR description.`,
 
 		// Generate PR description using Claude with spinner
 		s := spinner.New(spinner.CharSets[11], 100*time.Millisecond)
-		s.Suffix = " Analyzing changes and generating PR description with Claude..."
+		s.Suffix = fmt.Sprintf(" Analyzing changes and generating PR description with %s...", App.AI.Name())
 		s.Start()
 
 		prDescription, err := generatePRDescription(currentBranch)
@@ -73,26 +80,21 @@ func init() {
 	prCmd.Flags().StringP("title", "t", "", "PR title (Claude will generate if not provided)")
 }
 
-func getCurrentBranch() (string, error) {
-	cmd := exec.Command("git", "branch", "--show-current")
-	output, err := cmd.Output()
-	if err != nil {
-		return "", err
-	}
-	return strings.TrimSpace(string(output)), nil
-}
-
 func checkGHCLI() error {
 	// Check if gh is installed
 	cmd := exec.Command("gh", "--version")
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("gh CLI not found")
+		return errs.NewErrorWithHint("check gh CLI", fmt.Errorf("gh CLI not found"),
+			"Install the GitHub CLI (https://cli.github.com/), then run `gh auth login`.")
 	}
 
 	// Check if gh is authenticated
+	var stderr bytes.Buffer
 	cmd = exec.Command("gh", "auth", "status")
+	cmd.Stderr = &stderr
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("gh CLI not authenticated")
+		hint := errs.WithStderr("Run `gh auth login` to authenticate the GitHub CLI.", stderr.String(), Verbose)
+		return errs.NewErrorWithHint("check gh CLI", fmt.Errorf("gh CLI not authenticated"), hint)
 	}
 
 	return nil
@@ -111,60 +113,54 @@ func generatePRDescription(currentBranch string) (string, error) {
 	}
 
 	// Get the diff between current branch and base
-	diffCmd := exec.Command("git", "diff", fmt.Sprintf("%s...HEAD", baseBranch), "--name-status")
-	diffOutput, err := diffCmd.Output()
+	diffOutput, err := App.Git.DiffNameStatus(baseBranch, "HEAD")
 	if err != nil {
 		return "", fmt.Errorf("failed to get diff: %v", err)
 	}
 
 	// Get commit messages
-	logCmd := exec.Command("git", "log", fmt.Sprintf("%s..HEAD", baseBranch), "--oneline")
-	logOutput, err := logCmd.Output()
+	logOutput, err := App.Git.LogOneLine(baseBranch, "HEAD")
 	if err != nil {
 		return "", fmt.Errorf("failed to get commit log: %v", err)
 	}
 
-	// Get detailed diff for context
-	detailedDiffCmd := exec.Command("git", "diff", fmt.Sprintf("%s...HEAD", baseBranch), "--stat")
-	detailedDiffOutput, err := detailedDiffCmd.Output()
+	// Get detailed diff for context (--stat isn't part of the Client
+	// abstraction since nothing else needs it; fall back to gitcmd directly).
+	detailedDiffOutput, _, err := gitcmd.New(context.Background(), "diff").
+		AddDynamicArguments(fmt.Sprintf("%s...HEAD", baseBranch)).
+		AddArguments("--stat").
+		RunStdString(nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to get detailed diff: %v", err)
 	}
 
-	// Use Claude to analyze changes and generate PR description
-	prompt := fmt.Sprintf(`Based on the following git changes between %s and %s branches, create a pull request using the gh CLI.
-
-Changed Files:
-%s
-
-Commit History:
-%s
-
-Detailed Changes:
-%s
+	// Use the configured AI provider to analyze changes and generate a PR description.
+	repoRoot, err := App.Git.RepoRoot()
+	if err != nil {
+		repoRoot = ""
+	}
 
-Please use the gh pr create command with the following requirements:
-1. Generate a concise, descriptive PR title
-2. Create a comprehensive PR body that includes:
-   - A summary section with 2-3 bullet points explaining what this PR does
-   - A test plan section with specific testing criteria and checklist items
-3. Use the --allowedTools flag to enable the gh tool
-4. The PR body should be well-formatted with markdown
-5. Include "🤖 Generated with Claude Code" at the end of the body
+	prompt, err := prompts.Render(repoRoot, "pr", prompts.PRData{
+		CurrentBranch: currentBranch,
+		BaseBranch:    baseBranch,
+		Diff:          diffOutput,
+		Log:           logOutput,
+		DetailedDiff:  detailedDiffOutput,
+	})
+	if err != nil {
+		return "", err
+	}
 
-Important: Execute the gh pr create command directly. Do not just return the command or description text.`,
-		currentBranch, baseBranch,
-		string(diffOutput),
-		string(logOutput),
-		string(detailedDiffOutput))
+	if tc, ok := App.AI.(ai.ToolCapable); !ok || !tc.SupportsToolExecution() {
+		return "", fmt.Errorf("%s cannot run `gh pr create` itself; use the claude-cli provider for `bruh pr create`", App.AI.Name())
+	}
 
-	claudeCmd := exec.Command("claude", "--print", "--allowedTools", "Bash(gh:*)", "--", prompt)
-	output, err := claudeCmd.Output()
+	output, err := App.AI.Generate(context.Background(), prompt, ai.GenerateOpts{AllowedTools: "Bash(gh:*)"})
 	if err != nil {
-		return "", fmt.Errorf("failed to create PR with Claude: %v", err)
+		return "", err
 	}
 
-	return strings.TrimSpace(string(output)), nil
+	return strings.TrimSpace(output), nil
 }
 
 func createPR(description string) (string, error) {
@@ -1,13 +1,19 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/MaiMarincic/bruh/config"
+	"github.com/MaiMarincic/bruh/internal/errs"
 	"github.com/spf13/cobra"
 )
 
+// Verbose is set from the global --verbose flag. Error hints append the raw
+// stderr of the underlying command when this is true.
+var Verbose bool
+
 var rootCmd = &cobra.Command{
 	Use:   "bruh",
 	Short: "Just usefull commands",
@@ -20,12 +26,22 @@ var rootCmd = &cobra.Command{
 
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		printError(err)
 		os.Exit(1)
 	}
 }
 
+func printError(err error) {
+	var hintErr *errs.ErrorWithHint
+	if errors.As(err, &hintErr) {
+		fmt.Fprintf(os.Stderr, "Error: %s: %v\n\nHint:\n%s\n", hintErr.Task, hintErr.Err, hintErr.Hint)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+}
+
 func init() {
+	rootCmd.PersistentFlags().BoolVar(&Verbose, "verbose", false, "Include raw command stderr in error hints")
 	rootCmd.AddCommand(branchCmd)
 	rootCmd.AddCommand(prCmd)
 }
@@ -1,15 +1,22 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
+	"runtime"
 	"strings"
 
-	"github.com/briandowns/spinner"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+
+	"github.com/MaiMarincic/bruh/config"
+	"github.com/MaiMarincic/bruh/internal/govuln"
+	"github.com/MaiMarincic/bruh/internal/scanjobs"
+	"github.com/MaiMarincic/bruh/internal/scantargets"
 )
 
 var scanCmd = &cobra.Command{
@@ -43,11 +50,39 @@ Java:
 
 Rust:
 - cargo clippy: Rust linting
-- cargo audit: Rust vulnerability checker`,
+- cargo audit: Rust vulnerability checker
+
+Scanners for the detected languages run concurrently, bounded by --jobs,
+with a live progress line per in-flight scanner. Ctrl-C cancels any
+scanner still running and tears down its child process.
+
+Use --baseline on a legacy codebase to only fail on findings that
+weren't already present when the baseline was written with
+--write-baseline; --update-baseline then drops fingerprints for
+findings that have since been fixed.
+
+Positional args scope the scan to Go-style patterns, same as "go build":
+"./cmd/..." covers a package tree, "./pkg/foo" a single package, and a
+leading "!" excludes a pattern instead of including it (e.g.
+"!./vendor/..."). With no positional args the whole tree is scanned.
+config.Config's scan.exclude list applies the same way without needing
+to be retyped on the command line.
+
+--create-issues files one GitHub issue per unique high-severity finding
+via the gh CLI, reusing an existing open issue (matched by the same
+fingerprint --baseline uses) instead of filing a duplicate. Set
+pr.issue_repo in config to file into a repo other than the current one.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		verbose, _ := cmd.Flags().GetBool("verbose")
 		all, _ := cmd.Flags().GetBool("all")
 		language, _ := cmd.Flags().GetString("language")
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+		jobs, _ := cmd.Flags().GetInt("jobs")
+		baseline, _ := cmd.Flags().GetString("baseline")
+		writeBaseline, _ := cmd.Flags().GetString("write-baseline")
+		updateBaseline, _ := cmd.Flags().GetBool("update-baseline")
+		createIssues, _ := cmd.Flags().GetBool("create-issues")
 
 		var secrets, security, static, vulns bool
 		if all {
@@ -59,7 +94,36 @@ Rust:
 			vulns, _ = cmd.Flags().GetBool("vulns")
 		}
 
-		return runMultiLanguageScan(verbose, secrets, security, static, vulns, language)
+		targets, err := scantargets.Resolve(args, config.Get().Scan.Exclude)
+		if err != nil {
+			return fmt.Errorf("failed to resolve scan targets: %v", err)
+		}
+
+		if updateBaseline && baseline == "" {
+			return fmt.Errorf("--update-baseline requires --baseline")
+		}
+		if baseline != "" || writeBaseline != "" {
+			if format != "" && format != "text" && format != "json" && format != "sarif" {
+				return fmt.Errorf("invalid --format %q: must be text, json, or sarif", format)
+			}
+			return runBaselineScan(secrets, security, static, vulns, language, format, output, baseline, writeBaseline, updateBaseline, targets)
+		}
+
+		if createIssues {
+			if format != "" && format != "text" && format != "json" && format != "sarif" {
+				return fmt.Errorf("invalid --format %q: must be text, json, or sarif", format)
+			}
+			return runCreateIssuesScan(secrets, security, static, vulns, language, format, output, targets)
+		}
+
+		if format != "" && format != "text" {
+			if format != "json" && format != "sarif" {
+				return fmt.Errorf("invalid --format %q: must be text, json, or sarif", format)
+			}
+			return runReportScan(secrets, security, static, vulns, language, format, output, targets)
+		}
+
+		return runMultiLanguageScan(verbose, secrets, security, static, vulns, language, jobs, targets)
 	},
 }
 
@@ -71,9 +135,53 @@ func init() {
 	scanCmd.Flags().BoolP("all", "a", false, "Run all available scans for detected languages")
 	scanCmd.Flags().StringP("language", "l", "", "Force specific language (go, js, python, java, rust)")
 	scanCmd.Flags().BoolP("verbose", "v", false, "Enable verbose output")
+	scanCmd.Flags().String("format", "text", "Output format: text, json, or sarif. json/sarif aggregate every scanner's findings into a single report.Report instead of printing colored text")
+	scanCmd.Flags().String("output", "", "Write the json/sarif report to this file instead of stdout")
+	scanCmd.Flags().Int("jobs", runtime.NumCPU(), "Maximum number of scanners to run concurrently")
+	scanCmd.Flags().String("baseline", "", "Diff this run's findings against a baseline file; only new findings fail the scan")
+	scanCmd.Flags().String("write-baseline", "", "Write this run's findings as a new baseline to this path instead of failing on them")
+	scanCmd.Flags().Bool("update-baseline", false, "With --baseline, rewrite it to drop fingerprints no longer present, without admitting new ones")
+	scanCmd.Flags().Bool("create-issues", false, "File a GitHub issue per unique high-severity finding via the gh CLI, updating existing ones instead of duplicating")
+
+	scanCmd.AddCommand(scanDumpCmd)
+	rootCmd.AddCommand(scanCmd)
 }
 
-func runMultiLanguageScan(verbose, secrets, security, static, vulns bool, forceLanguage string) error {
+// runReportScan is the json/sarif counterpart to runMultiLanguageScan: it
+// runs the same scanners in their JSON-output modes, aggregates the result
+// into a report.Report, and serializes that instead of printing colored
+// text per tool.
+func runReportScan(secrets, security, static, vulns bool, forceLanguage, format, output string, targets scantargets.Set) error {
+	if !secrets && !security && !static && !vulns {
+		fmt.Println("No scan types selected. Use --all or enable specific scans.")
+		return nil
+	}
+
+	languages := []string{forceLanguage}
+	if forceLanguage == "" {
+		languages = detectLanguages()
+	}
+	if len(languages) == 0 {
+		color.Yellow("⚠️  No supported languages detected in current directory")
+		return nil
+	}
+
+	r, err := collectReport(languages, secrets, security, static, vulns, targets)
+	if err != nil {
+		return err
+	}
+
+	if err := writeReport(r, format, output); err != nil {
+		return err
+	}
+
+	if r.HasErrors() {
+		return fmt.Errorf("scan found %d finding(s)", len(r.Findings))
+	}
+	return nil
+}
+
+func runMultiLanguageScan(verbose, secrets, security, static, vulns bool, forceLanguage string, jobs int, targets scantargets.Set) error {
 	if !secrets && !security && !static && !vulns {
 		fmt.Println("No scan types selected. Use --all or enable specific scans.")
 		return nil
@@ -95,40 +203,29 @@ func runMultiLanguageScan(verbose, secrets, security, static, vulns bool, forceL
 		fmt.Printf("Detected languages: %s\n", strings.Join(languages, ", "))
 	}
 
-	hasErrors := false
+	scanners := buildScanners(verbose, secrets, security, static, vulns, languages, targets)
+	if len(scanners) == 0 {
+		color.Green("✅ All enabled scans completed successfully")
+		return nil
+	}
 
-	// Run multi-language tools first
-	if secrets {
-		if err := runGitleaksScan(verbose); err != nil {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	hasErrors := false
+	results := scanjobs.NewPool(jobs).Run(ctx, scanners, func(result scanjobs.Result) {
+		printScanResult(result)
+		if result.Err != nil {
 			hasErrors = true
-			color.Red("❌ Secrets scan failed: %v", err)
 		}
+	})
+
+	if ctx.Err() != nil {
+		return fmt.Errorf("scan canceled: %v", ctx.Err())
 	}
 
-	// Run language-specific tools
-	for _, lang := range languages {
-		switch lang {
-		case "go":
-			if err := runGoScans(verbose, security, static, vulns); err != nil {
-				hasErrors = true
-			}
-		case "javascript", "typescript":
-			if err := runJavaScriptScans(verbose, security, static, vulns); err != nil {
-				hasErrors = true
-			}
-		case "python":
-			if err := runPythonScans(verbose, security, static, vulns); err != nil {
-				hasErrors = true
-			}
-		case "java":
-			if err := runJavaScans(verbose, security, static, vulns); err != nil {
-				hasErrors = true
-			}
-		case "rust":
-			if err := runRustScans(verbose, security, static, vulns); err != nil {
-				hasErrors = true
-			}
-		}
+	if skipped := len(scanners) - len(results); skipped > 0 {
+		color.Yellow("⚠️  %d scanner(s) were not installed and were skipped", skipped)
 	}
 
 	if hasErrors {
@@ -139,6 +236,19 @@ func runMultiLanguageScan(verbose, secrets, security, static, vulns bool, forceL
 	return nil
 }
 
+// printScanResult prints a finished scanner's Result as the colored
+// summary line previously printed inline by each run*Scan function.
+func printScanResult(result scanjobs.Result) {
+	if result.Err != nil {
+		color.Red("🚨 %s: %v", result.Name, result.Err)
+		if result.Message != "" {
+			fmt.Println(result.Message)
+		}
+		return
+	}
+	color.Green("✅ %s", result.Message)
+}
+
 func detectLanguages() []string {
 	languages := []string{}
 
@@ -207,45 +317,37 @@ func hasFilesWithExtension(extensions ...string) bool {
 	return found
 }
 
-func runGitleaksScan(verbose bool) error {
-	s := spinner.New(spinner.CharSets[14], 100)
-	s.Suffix = " Scanning for committed secrets with gitleaks..."
-	s.Start()
-
-	if !isGitleaksInstalled() {
-		s.Stop()
-		color.Yellow("⚠️  gitleaks is not installed. Install it with:")
-		fmt.Println("   brew install gitleaks")
-		fmt.Println("   or visit: https://github.com/gitleaks/gitleaks")
-		return nil
-	}
+// The run*Scan functions below each execute one backing tool and return a
+// human-readable summary: on success, a short "no issues" line; on
+// failure (including the tool finding something to report), the tool's
+// own output. They no longer print directly - that's now
+// printScanResult's job, called once a scanner's Result comes back from
+// the worker pool - and they take ctx so Ctrl-C tears down the child
+// process they spawned.
 
+func runGitleaksScan(ctx context.Context, verbose bool) (string, error) {
 	args := []string{"detect", "--verbose"}
 	if !verbose {
 		args = []string{"detect"}
 	}
 
-	cmd := exec.Command("gitleaks", args...)
+	cmd := exec.CommandContext(ctx, "gitleaks", args...)
 	output, err := cmd.CombinedOutput()
-	s.Stop()
 
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
 			if exitError.ExitCode() == 1 {
-				color.Red("🚨 Secrets detected!")
-				fmt.Println(string(output))
-				return fmt.Errorf("gitleaks found potential secrets in the repository")
+				return string(output), fmt.Errorf("gitleaks found potential secrets in the repository")
 			}
 		}
-		return fmt.Errorf("failed to run gitleaks: %v", err)
+		return "", fmt.Errorf("failed to run gitleaks: %v", err)
 	}
 
-	color.Green("✅ No secrets detected by gitleaks")
+	message := "No secrets detected by gitleaks"
 	if verbose {
-		fmt.Println(string(output))
+		message += "\n" + string(output)
 	}
-
-	return nil
+	return message, nil
 }
 
 func isGitleaksInstalled() bool {
@@ -253,149 +355,94 @@ func isGitleaksInstalled() bool {
 	return err == nil
 }
 
-func runGosecScan(verbose bool) error {
-	s := spinner.New(spinner.CharSets[14], 100)
-	s.Suffix = " Running Go security analysis with gosec..."
-	s.Start()
-
-	if !isToolInstalled("gosec") {
-		s.Stop()
-		color.Yellow("⚠️  gosec is not installed. Install it with:")
-		fmt.Println("   go install github.com/securecodewarrior/gosec/v2/cmd/gosec@latest")
-		return nil
-	}
-
-	args := []string{"./..."}
+func runGosecScan(ctx context.Context, verbose bool, targets scantargets.Set) (string, error) {
+	args := append([]string{}, targets.GoPackages...)
 	if verbose {
 		args = append([]string{"-verbose"}, args...)
 	}
 
-	cmd := exec.Command("gosec", args...)
+	cmd := exec.CommandContext(ctx, "gosec", args...)
 	output, err := cmd.CombinedOutput()
-	s.Stop()
 
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
 			if exitError.ExitCode() == 1 {
-				color.Red("🚨 Security issues detected!")
-				fmt.Println(string(output))
-				return fmt.Errorf("gosec found security issues")
+				return string(output), fmt.Errorf("gosec found security issues")
 			}
 		}
-		return fmt.Errorf("failed to run gosec: %v", err)
+		return "", fmt.Errorf("failed to run gosec: %v", err)
 	}
 
-	color.Green("✅ No security issues detected by gosec")
+	message := "No security issues detected by gosec"
 	if verbose {
-		fmt.Println(string(output))
+		message += "\n" + string(output)
 	}
-
-	return nil
+	return message, nil
 }
 
-func runStaticcheckScan(verbose bool) error {
-	s := spinner.New(spinner.CharSets[14], 100)
-	s.Suffix = " Running static analysis with staticcheck..."
-	s.Start()
-
-	if !isToolInstalled("staticcheck") {
-		s.Stop()
-		color.Yellow("⚠️  staticcheck is not installed. Install it with:")
-		fmt.Println("   go install honnef.co/go/tools/cmd/staticcheck@latest")
-		return nil
-	}
-
-	args := []string{"./..."}
-
-	cmd := exec.Command("staticcheck", args...)
+func runStaticcheckScan(ctx context.Context, verbose bool, targets scantargets.Set) (string, error) {
+	cmd := exec.CommandContext(ctx, "staticcheck", targets.GoPackages...)
 	output, err := cmd.CombinedOutput()
-	s.Stop()
 
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
 			if exitError.ExitCode() == 1 {
-				color.Red("🚨 Static analysis issues detected!")
-				fmt.Println(string(output))
-				return fmt.Errorf("staticcheck found issues")
+				return string(output), fmt.Errorf("staticcheck found issues")
 			}
 		}
-		return fmt.Errorf("failed to run staticcheck: %v", err)
+		return "", fmt.Errorf("failed to run staticcheck: %v", err)
 	}
 
 	if len(output) > 0 {
-		color.Red("🚨 Static analysis issues detected!")
-		fmt.Println(string(output))
-		return fmt.Errorf("staticcheck found issues")
+		return string(output), fmt.Errorf("staticcheck found issues")
 	}
 
-	color.Green("✅ No static analysis issues detected by staticcheck")
+	message := "No static analysis issues detected by staticcheck"
 	if verbose {
-		fmt.Println("staticcheck completed successfully")
+		message += "\nstaticcheck completed successfully"
 	}
-
-	return nil
+	return message, nil
 }
 
-func runGoVetScan(verbose bool) error {
-	s := spinner.New(spinner.CharSets[14], 100)
-	s.Suffix = " Running go vet analysis..."
-	s.Start()
-
-	args := []string{"vet", "./..."}
-
-	cmd := exec.Command("go", args...)
+func runGoVetScan(ctx context.Context, verbose bool, targets scantargets.Set) (string, error) {
+	cmd := exec.CommandContext(ctx, "go", append([]string{"vet"}, targets.GoPackages...)...)
 	output, err := cmd.CombinedOutput()
-	s.Stop()
 
 	if err != nil {
-		color.Red("🚨 Go vet issues detected!")
-		fmt.Println(string(output))
-		return fmt.Errorf("go vet found issues")
+		return string(output), fmt.Errorf("go vet found issues")
 	}
 
-	color.Green("✅ No issues detected by go vet")
+	message := "No issues detected by go vet"
 	if verbose && len(output) > 0 {
-		fmt.Println(string(output))
+		message += "\n" + string(output)
 	}
-
-	return nil
+	return message, nil
 }
 
-func runGovulncheckScan(verbose bool) error {
-	s := spinner.New(spinner.CharSets[14], 100)
-	s.Suffix = " Checking for known vulnerabilities with govulncheck..."
-	s.Start()
-
-	if !isToolInstalled("govulncheck") {
-		s.Stop()
-		color.Yellow("⚠️  govulncheck is not installed. Install it with:")
-		fmt.Println("   go install golang.org/x/vuln/cmd/govulncheck@latest")
-		return nil
+func runGovulncheckScan(ctx context.Context, verbose bool, targets scantargets.Set) (string, error) {
+	output, err := govuln.Run(ctx, targets.GoPackages...)
+	if err != nil {
+		return "", fmt.Errorf("failed to run govulncheck: %v", err)
 	}
 
-	args := []string{"./..."}
-
-	cmd := exec.Command("govulncheck", args...)
-	output, err := cmd.CombinedOutput()
-	s.Stop()
-
+	findings, err := parseGovulncheckJSON(output)
 	if err != nil {
-		if exitError, ok := err.(*exec.ExitError); ok {
-			if exitError.ExitCode() == 3 {
-				color.Red("🚨 Known vulnerabilities detected!")
-				fmt.Println(string(output))
-				return fmt.Errorf("govulncheck found vulnerabilities")
-			}
+		return "", fmt.Errorf("failed to parse govulncheck output: %v", err)
+	}
+
+	if len(findings) > 0 {
+		var b strings.Builder
+		for _, f := range findings {
+			fmt.Fprintf(&b, "  - %s: %s\n", f.RuleID, f.Message)
 		}
-		return fmt.Errorf("failed to run govulncheck: %v", err)
+		return b.String(), fmt.Errorf("govulncheck found vulnerabilities")
 	}
 
-	color.Green("✅ No known vulnerabilities detected by govulncheck")
+	message := "No known vulnerabilities detected by govulncheck"
 	if verbose {
-		fmt.Println(string(output))
+		message += "\n" + string(output)
 	}
-
-	return nil
+	return message, nil
 }
 
 func isToolInstalled(tool string) bool {
@@ -403,491 +450,329 @@ func isToolInstalled(tool string) bool {
 	return err == nil
 }
 
-// Language-specific scan functions
-
-func runGoScans(verbose, security, static, vulns bool) error {
-	hasErrors := false
-
-	if static {
-		if err := runGoVetScan(verbose); err != nil {
-			hasErrors = true
-			color.Red("❌ Go vet failed: %v", err)
-		}
-
-		if err := runStaticcheckScan(verbose); err != nil {
-			hasErrors = true
-			color.Red("❌ Static analysis failed: %v", err)
-		}
-	}
-
-	if security {
-		if err := runGosecScan(verbose); err != nil {
-			hasErrors = true
-			color.Red("❌ Security scan failed: %v", err)
-		}
-	}
-
-	if vulns {
-		if err := runGovulncheckScan(verbose); err != nil {
-			hasErrors = true
-			color.Red("❌ Vulnerability scan failed: %v", err)
-		}
-	}
-
-	if hasErrors {
-		return fmt.Errorf("Go scans detected issues")
-	}
-	return nil
-}
-
-func runJavaScriptScans(verbose, security, static, vulns bool) error {
-	hasErrors := false
+// buildScanners assembles the scanjobs.Scanner list to run for the
+// enabled scan types across the detected languages. targets scopes the
+// tools that support per-path invocation (the Go tools, eslint, bandit,
+// pylint); the rest (npm audit, safety, the Java/Rust tools) operate on
+// the whole project regardless, since their own tooling has no per-path
+// scan mode.
+func buildScanners(verbose, secrets, security, static, vulns bool, languages []string, targets scantargets.Set) []scanjobs.Scanner {
+	var scanners []scanjobs.Scanner
 
-	if static {
-		if err := runESLintScan(verbose); err != nil {
-			hasErrors = true
-			color.Red("❌ ESLint failed: %v", err)
-		}
+	add := func(name string, available func() bool, run func(ctx context.Context) (string, error)) {
+		scanners = append(scanners, toolScanner{name: name, available: available, run: run})
 	}
 
-	if vulns {
-		if err := runNpmAuditScan(verbose); err != nil {
-			hasErrors = true
-			color.Red("❌ NPM audit failed: %v", err)
-		}
-	}
-
-	if hasErrors {
-		return fmt.Errorf("JavaScript/TypeScript scans detected issues")
-	}
-	return nil
-}
-
-func runPythonScans(verbose, security, static, vulns bool) error {
-	hasErrors := false
-
-	if security {
-		if err := runBanditScan(verbose); err != nil {
-			hasErrors = true
-			color.Red("❌ Bandit security scan failed: %v", err)
-		}
+	if secrets {
+		add("gitleaks", isGitleaksInstalled, func(ctx context.Context) (string, error) {
+			return runGitleaksScan(ctx, verbose)
+		})
 	}
 
-	if static {
-		if err := runPylintScan(verbose); err != nil {
-			hasErrors = true
-			color.Red("❌ Pylint failed: %v", err)
-		}
-	}
+	alwaysAvailable := func() bool { return true }
 
-	if vulns {
-		if err := runSafetyScan(verbose); err != nil {
-			hasErrors = true
-			color.Red("❌ Safety vulnerability scan failed: %v", err)
+	for _, lang := range languages {
+		switch lang {
+		case "go":
+			if static {
+				add("go vet", alwaysAvailable, func(ctx context.Context) (string, error) {
+					return runGoVetScan(ctx, verbose, targets)
+				})
+				add("staticcheck", func() bool { return isToolInstalled("staticcheck") }, func(ctx context.Context) (string, error) {
+					return runStaticcheckScan(ctx, verbose, targets)
+				})
+			}
+			if security {
+				add("gosec", func() bool { return isToolInstalled("gosec") }, func(ctx context.Context) (string, error) {
+					return runGosecScan(ctx, verbose, targets)
+				})
+			}
+			if vulns {
+				add("govulncheck", alwaysAvailable, func(ctx context.Context) (string, error) {
+					return runGovulncheckScan(ctx, verbose, targets)
+				})
+			}
+		case "javascript", "typescript":
+			if static {
+				add("eslint", func() bool { return isToolInstalled("eslint") }, func(ctx context.Context) (string, error) {
+					return runESLintScan(ctx, verbose, targets)
+				})
+			}
+			if vulns {
+				add("npm audit", func() bool { return isToolInstalled("npm") }, func(ctx context.Context) (string, error) {
+					return runNpmAuditScan(ctx, verbose)
+				})
+			}
+		case "python":
+			if security {
+				add("bandit", func() bool { return isToolInstalled("bandit") }, func(ctx context.Context) (string, error) {
+					return runBanditScan(ctx, verbose, targets)
+				})
+			}
+			if static {
+				add("pylint", func() bool { return isToolInstalled("pylint") }, func(ctx context.Context) (string, error) {
+					return runPylintScan(ctx, verbose, targets)
+				})
+			}
+			if vulns {
+				add("safety", func() bool { return isToolInstalled("safety") }, func(ctx context.Context) (string, error) {
+					return runSafetyScan(ctx, verbose)
+				})
+			}
+		case "java":
+			if static {
+				add("spotbugs", func() bool { return isToolInstalled("spotbugs") }, func(ctx context.Context) (string, error) {
+					return runSpotBugsScan(ctx, verbose)
+				})
+				add("pmd", func() bool { return isToolInstalled("pmd") }, func(ctx context.Context) (string, error) {
+					return runPMDScan(ctx, verbose)
+				})
+			}
+		case "rust":
+			if static {
+				add("cargo clippy", func() bool { return isToolInstalled("cargo") }, func(ctx context.Context) (string, error) {
+					return runCargoClippyScan(ctx, verbose)
+				})
+			}
+			if vulns {
+				add("cargo audit", func() bool { return isToolInstalled("cargo-audit") }, func(ctx context.Context) (string, error) {
+					return runCargoAuditScan(ctx, verbose)
+				})
+			}
 		}
 	}
 
-	if hasErrors {
-		return fmt.Errorf("Python scans detected issues")
-	}
-	return nil
+	return scanners
 }
 
-func runJavaScans(verbose, security, static, vulns bool) error {
-	hasErrors := false
-
-	if static {
-		if err := runSpotBugsScan(verbose); err != nil {
-			hasErrors = true
-			color.Red("❌ SpotBugs failed: %v", err)
-		}
-
-		if err := runPMDScan(verbose); err != nil {
-			hasErrors = true
-			color.Red("❌ PMD failed: %v", err)
-		}
-	}
-
-	if hasErrors {
-		return fmt.Errorf("Java scans detected issues")
-	}
-	return nil
+// toolScanner adapts one of the run*Scan functions above to the
+// scanjobs.Scanner interface.
+type toolScanner struct {
+	name      string
+	available func() bool
+	run       func(ctx context.Context) (string, error)
 }
 
-func runRustScans(verbose, security, static, vulns bool) error {
-	hasErrors := false
+func (t toolScanner) Name() string    { return t.name }
+func (t toolScanner) Available() bool { return t.available() }
 
-	if static {
-		if err := runCargoClippyScan(verbose); err != nil {
-			hasErrors = true
-			color.Red("❌ Cargo clippy failed: %v", err)
-		}
-	}
-
-	if vulns {
-		if err := runCargoAuditScan(verbose); err != nil {
-			hasErrors = true
-			color.Red("❌ Cargo audit failed: %v", err)
-		}
-	}
-
-	if hasErrors {
-		return fmt.Errorf("Rust scans detected issues")
-	}
-	return nil
+func (t toolScanner) Run(ctx context.Context) scanjobs.Result {
+	message, err := t.run(ctx)
+	return scanjobs.Result{Name: t.name, Message: message, Err: err}
 }
 
 // JavaScript/TypeScript tool implementations
 
-func runESLintScan(verbose bool) error {
-	s := spinner.New(spinner.CharSets[14], 100)
-	s.Suffix = " Running ESLint analysis..."
-	s.Start()
-
-	if !isToolInstalled("eslint") {
-		s.Stop()
-		color.Yellow("⚠️  eslint is not installed. Install it with:")
-		fmt.Println("   npm install -g eslint")
-		return nil
-	}
-
-	args := []string{".", "--ext", ".js,.jsx,.ts,.tsx"}
+func runESLintScan(ctx context.Context, verbose bool, targets scantargets.Set) (string, error) {
+	args := append(append([]string{}, targets.Dirs...), "--ext", ".js,.jsx,.ts,.tsx")
 	if !verbose {
 		args = append(args, "--quiet")
 	}
 
-	cmd := exec.Command("eslint", args...)
+	cmd := exec.CommandContext(ctx, "eslint", args...)
 	output, err := cmd.CombinedOutput()
-	s.Stop()
 
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
 			if exitError.ExitCode() == 1 {
-				color.Red("🚨 ESLint issues detected!")
-				fmt.Println(string(output))
-				return fmt.Errorf("eslint found issues")
+				return string(output), fmt.Errorf("eslint found issues")
 			}
 		}
-		return fmt.Errorf("failed to run eslint: %v", err)
+		return "", fmt.Errorf("failed to run eslint: %v", err)
 	}
 
-	color.Green("✅ No ESLint issues detected")
+	message := "No ESLint issues detected"
 	if verbose && len(output) > 0 {
-		fmt.Println(string(output))
+		message += "\n" + string(output)
 	}
-
-	return nil
+	return message, nil
 }
 
-func runNpmAuditScan(verbose bool) error {
-	s := spinner.New(spinner.CharSets[14], 100)
-	s.Suffix = " Running NPM audit..."
-	s.Start()
-
-	if !isToolInstalled("npm") {
-		s.Stop()
-		color.Yellow("⚠️  npm is not installed")
-		return nil
-	}
-
+func runNpmAuditScan(ctx context.Context, verbose bool) (string, error) {
 	args := []string{"audit"}
 	if !verbose {
 		args = append(args, "--audit-level", "moderate")
 	}
 
-	cmd := exec.Command("npm", args...)
+	cmd := exec.CommandContext(ctx, "npm", args...)
 	output, err := cmd.CombinedOutput()
-	s.Stop()
 
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
 			if exitError.ExitCode() == 1 {
-				color.Red("🚨 NPM vulnerabilities detected!")
-				fmt.Println(string(output))
-				return fmt.Errorf("npm audit found vulnerabilities")
+				return string(output), fmt.Errorf("npm audit found vulnerabilities")
 			}
 		}
-		return fmt.Errorf("failed to run npm audit: %v", err)
+		return "", fmt.Errorf("failed to run npm audit: %v", err)
 	}
 
-	color.Green("✅ No NPM vulnerabilities detected")
+	message := "No NPM vulnerabilities detected"
 	if verbose {
-		fmt.Println(string(output))
+		message += "\n" + string(output)
 	}
-
-	return nil
+	return message, nil
 }
 
 // Python tool implementations
 
-func runBanditScan(verbose bool) error {
-	s := spinner.New(spinner.CharSets[14], 100)
-	s.Suffix = " Running Bandit security analysis..."
-	s.Start()
-
-	if !isToolInstalled("bandit") {
-		s.Stop()
-		color.Yellow("⚠️  bandit is not installed. Install it with:")
-		fmt.Println("   pip install bandit")
-		return nil
-	}
-
-	args := []string{"-r", "."}
+func runBanditScan(ctx context.Context, verbose bool, targets scantargets.Set) (string, error) {
+	args := append([]string{"-r"}, targets.Dirs...)
 	if !verbose {
 		args = append(args, "-q")
 	}
 
-	cmd := exec.Command("bandit", args...)
+	cmd := exec.CommandContext(ctx, "bandit", args...)
 	output, err := cmd.CombinedOutput()
-	s.Stop()
 
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
 			if exitError.ExitCode() == 1 {
-				color.Red("🚨 Bandit security issues detected!")
-				fmt.Println(string(output))
-				return fmt.Errorf("bandit found security issues")
+				return string(output), fmt.Errorf("bandit found security issues")
 			}
 		}
-		return fmt.Errorf("failed to run bandit: %v", err)
+		return "", fmt.Errorf("failed to run bandit: %v", err)
 	}
 
-	color.Green("✅ No security issues detected by Bandit")
+	message := "No security issues detected by Bandit"
 	if verbose {
-		fmt.Println(string(output))
+		message += "\n" + string(output)
 	}
-
-	return nil
+	return message, nil
 }
 
-func runPylintScan(verbose bool) error {
-	s := spinner.New(spinner.CharSets[14], 100)
-	s.Suffix = " Running Pylint analysis..."
-	s.Start()
-
-	if !isToolInstalled("pylint") {
-		s.Stop()
-		color.Yellow("⚠️  pylint is not installed. Install it with:")
-		fmt.Println("   pip install pylint")
-		return nil
-	}
-
-	args := []string{"**/*.py"}
+func runPylintScan(ctx context.Context, verbose bool, targets scantargets.Set) (string, error) {
+	args := append([]string{}, targets.Dirs...)
 	if !verbose {
 		args = append(args, "--errors-only")
 	}
 
-	cmd := exec.Command("pylint", args...)
+	cmd := exec.CommandContext(ctx, "pylint", args...)
 	output, err := cmd.CombinedOutput()
-	s.Stop()
 
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
 			// Pylint exit codes: 0=no issues, 1-32=various issues found
 			if exitError.ExitCode() <= 32 {
-				color.Red("🚨 Pylint issues detected!")
-				fmt.Println(string(output))
-				return fmt.Errorf("pylint found issues")
+				return string(output), fmt.Errorf("pylint found issues")
 			}
 		}
-		return fmt.Errorf("failed to run pylint: %v", err)
+		return "", fmt.Errorf("failed to run pylint: %v", err)
 	}
 
-	color.Green("✅ No Pylint issues detected")
+	message := "No Pylint issues detected"
 	if verbose {
-		fmt.Println(string(output))
+		message += "\n" + string(output)
 	}
-
-	return nil
+	return message, nil
 }
 
-func runSafetyScan(verbose bool) error {
-	s := spinner.New(spinner.CharSets[14], 100)
-	s.Suffix = " Running Safety vulnerability check..."
-	s.Start()
-
-	if !isToolInstalled("safety") {
-		s.Stop()
-		color.Yellow("⚠️  safety is not installed. Install it with:")
-		fmt.Println("   pip install safety")
-		return nil
-	}
-
+func runSafetyScan(ctx context.Context, verbose bool) (string, error) {
 	args := []string{"check"}
 	if !verbose {
 		args = append(args, "--short-report")
 	}
 
-	cmd := exec.Command("safety", args...)
+	cmd := exec.CommandContext(ctx, "safety", args...)
 	output, err := cmd.CombinedOutput()
-	s.Stop()
 
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
 			if exitError.ExitCode() == 64 {
-				color.Red("🚨 Safety vulnerabilities detected!")
-				fmt.Println(string(output))
-				return fmt.Errorf("safety found vulnerabilities")
+				return string(output), fmt.Errorf("safety found vulnerabilities")
 			}
 		}
-		return fmt.Errorf("failed to run safety: %v", err)
+		return "", fmt.Errorf("failed to run safety: %v", err)
 	}
 
-	color.Green("✅ No vulnerabilities detected by Safety")
+	message := "No vulnerabilities detected by Safety"
 	if verbose {
-		fmt.Println(string(output))
+		message += "\n" + string(output)
 	}
-
-	return nil
+	return message, nil
 }
 
 // Java tool implementations
 
-func runSpotBugsScan(verbose bool) error {
-	s := spinner.New(spinner.CharSets[14], 100)
-	s.Suffix = " Running SpotBugs analysis..."
-	s.Start()
-
-	if !isToolInstalled("spotbugs") {
-		s.Stop()
-		color.Yellow("⚠️  spotbugs is not installed. Install it from:")
-		fmt.Println("   https://spotbugs.github.io/")
-		return nil
-	}
-
-	args := []string{"-textui", "."}
-
-	cmd := exec.Command("spotbugs", args...)
+func runSpotBugsScan(ctx context.Context, verbose bool) (string, error) {
+	cmd := exec.CommandContext(ctx, "spotbugs", "-textui", ".")
 	output, err := cmd.CombinedOutput()
-	s.Stop()
 
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
 			if exitError.ExitCode() == 1 {
-				color.Red("🚨 SpotBugs issues detected!")
-				fmt.Println(string(output))
-				return fmt.Errorf("spotbugs found issues")
+				return string(output), fmt.Errorf("spotbugs found issues")
 			}
 		}
-		return fmt.Errorf("failed to run spotbugs: %v", err)
+		return "", fmt.Errorf("failed to run spotbugs: %v", err)
 	}
 
-	color.Green("✅ No issues detected by SpotBugs")
+	message := "No issues detected by SpotBugs"
 	if verbose {
-		fmt.Println(string(output))
+		message += "\n" + string(output)
 	}
-
-	return nil
+	return message, nil
 }
 
-func runPMDScan(verbose bool) error {
-	s := spinner.New(spinner.CharSets[14], 100)
-	s.Suffix = " Running PMD analysis..."
-	s.Start()
-
-	if !isToolInstalled("pmd") {
-		s.Stop()
-		color.Yellow("⚠️  PMD is not installed. Install it from:")
-		fmt.Println("   https://pmd.github.io/")
-		return nil
-	}
-
+func runPMDScan(ctx context.Context, verbose bool) (string, error) {
 	args := []string{"check", "-d", ".", "-R", "rulesets/java/quickstart.xml", "-f", "text"}
 
-	cmd := exec.Command("pmd", args...)
+	cmd := exec.CommandContext(ctx, "pmd", args...)
 	output, err := cmd.CombinedOutput()
-	s.Stop()
 
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
 			if exitError.ExitCode() == 4 {
-				color.Red("🚨 PMD issues detected!")
-				fmt.Println(string(output))
-				return fmt.Errorf("pmd found issues")
+				return string(output), fmt.Errorf("pmd found issues")
 			}
 		}
-		return fmt.Errorf("failed to run pmd: %v", err)
+		return "", fmt.Errorf("failed to run pmd: %v", err)
 	}
 
-	color.Green("✅ No issues detected by PMD")
+	message := "No issues detected by PMD"
 	if verbose {
-		fmt.Println(string(output))
+		message += "\n" + string(output)
 	}
-
-	return nil
+	return message, nil
 }
 
 // Rust tool implementations
 
-func runCargoClippyScan(verbose bool) error {
-	s := spinner.New(spinner.CharSets[14], 100)
-	s.Suffix = " Running Cargo clippy..."
-	s.Start()
-
-	if !isToolInstalled("cargo") {
-		s.Stop()
-		color.Yellow("⚠️  cargo is not installed")
-		return nil
-	}
-
-	args := []string{"clippy", "--", "-D", "warnings"}
-
-	cmd := exec.Command("cargo", args...)
+func runCargoClippyScan(ctx context.Context, verbose bool) (string, error) {
+	cmd := exec.CommandContext(ctx, "cargo", "clippy", "--", "-D", "warnings")
 	output, err := cmd.CombinedOutput()
-	s.Stop()
 
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
 			if exitError.ExitCode() == 101 {
-				color.Red("🚨 Clippy issues detected!")
-				fmt.Println(string(output))
-				return fmt.Errorf("cargo clippy found issues")
+				return string(output), fmt.Errorf("cargo clippy found issues")
 			}
 		}
-		return fmt.Errorf("failed to run cargo clippy: %v", err)
+		return "", fmt.Errorf("failed to run cargo clippy: %v", err)
 	}
 
-	color.Green("✅ No issues detected by Cargo clippy")
+	message := "No issues detected by Cargo clippy"
 	if verbose {
-		fmt.Println(string(output))
+		message += "\n" + string(output)
 	}
-
-	return nil
+	return message, nil
 }
 
-func runCargoAuditScan(verbose bool) error {
-	s := spinner.New(spinner.CharSets[14], 100)
-	s.Suffix = " Running Cargo audit..."
-	s.Start()
-
-	if !isToolInstalled("cargo-audit") {
-		s.Stop()
-		color.Yellow("⚠️  cargo-audit is not installed. Install it with:")
-		fmt.Println("   cargo install cargo-audit")
-		return nil
-	}
-
-	args := []string{"audit"}
-
-	cmd := exec.Command("cargo", args...)
+func runCargoAuditScan(ctx context.Context, verbose bool) (string, error) {
+	cmd := exec.CommandContext(ctx, "cargo", "audit")
 	output, err := cmd.CombinedOutput()
-	s.Stop()
 
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
 			if exitError.ExitCode() == 1 {
-				color.Red("🚨 Cargo audit vulnerabilities detected!")
-				fmt.Println(string(output))
-				return fmt.Errorf("cargo audit found vulnerabilities")
+				return string(output), fmt.Errorf("cargo audit found vulnerabilities")
 			}
 		}
-		return fmt.Errorf("failed to run cargo audit: %v", err)
+		return "", fmt.Errorf("failed to run cargo audit: %v", err)
 	}
 
-	color.Green("✅ No vulnerabilities detected by Cargo audit")
+	message := "No vulnerabilities detected by Cargo audit"
 	if verbose {
-		fmt.Println(string(output))
+		message += "\n" + string(output)
 	}
-
-	return nil
+	return message, nil
 }
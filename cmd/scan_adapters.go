@@ -0,0 +1,408 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/MaiMarincic/bruh/pkg/report"
+)
+
+// The parse* functions below each turn one backing tool's own JSON output
+// format into the normalized report.Finding shape. They're deliberately
+// tolerant of fields the tool might omit (e.g. a missing CWE) since the
+// goal is a best-effort normalized view, not a full re-implementation of
+// each tool's schema.
+
+func parseGosecJSON(data []byte) ([]report.Finding, error) {
+	var out struct {
+		Issues []struct {
+			Severity string `json:"severity"`
+			RuleID   string `json:"rule_id"`
+			Details  string `json:"details"`
+			File     string `json:"file"`
+			Line     string `json:"line"`
+			Cwe      struct {
+				ID string `json:"id"`
+			} `json:"cwe"`
+		} `json:"Issues"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	var findings []report.Finding
+	for _, issue := range out.Issues {
+		findings = append(findings, report.Finding{
+			Tool:     "gosec",
+			RuleID:   issue.RuleID,
+			Severity: gosecSeverity(issue.Severity),
+			File:     issue.File,
+			Line:     firstInt(issue.Line),
+			Message:  issue.Details,
+			CWE:      cweID(issue.Cwe.ID),
+		})
+	}
+	return findings, nil
+}
+
+func gosecSeverity(s string) report.Severity {
+	switch strings.ToUpper(s) {
+	case "HIGH", "MEDIUM":
+		return report.SeverityError
+	default:
+		return report.SeverityWarning
+	}
+}
+
+// parseStaticcheckJSON parses staticcheck's "-f json" output, which is one
+// JSON object per line rather than a single array.
+func parseStaticcheckJSON(data []byte) ([]report.Finding, error) {
+	var findings []report.Finding
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry struct {
+			Code     string `json:"code"`
+			Severity string `json:"severity"`
+			Location struct {
+				File string `json:"file"`
+				Line int    `json:"line"`
+			} `json:"location"`
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return findings, err
+		}
+
+		severity := report.SeverityWarning
+		if entry.Severity == "error" {
+			severity = report.SeverityError
+		}
+
+		findings = append(findings, report.Finding{
+			Tool:     "staticcheck",
+			RuleID:   entry.Code,
+			Severity: severity,
+			File:     entry.Location.File,
+			Line:     entry.Location.Line,
+			Message:  entry.Message,
+		})
+	}
+
+	return findings, scanner.Err()
+}
+
+func parseSemgrepJSON(data []byte) ([]report.Finding, error) {
+	var out struct {
+		Results []struct {
+			CheckID string `json:"check_id"`
+			Path    string `json:"path"`
+			Start   struct {
+				Line int `json:"line"`
+			} `json:"start"`
+			Extra struct {
+				Message  string `json:"message"`
+				Severity string `json:"severity"`
+				Metadata struct {
+					Cwe []string `json:"cwe"`
+				} `json:"metadata"`
+			} `json:"extra"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	var findings []report.Finding
+	for _, r := range out.Results {
+		var cwe string
+		if len(r.Extra.Metadata.Cwe) > 0 {
+			cwe = r.Extra.Metadata.Cwe[0]
+		}
+		findings = append(findings, report.Finding{
+			Tool:     "semgrep",
+			RuleID:   r.CheckID,
+			Severity: semgrepSeverity(r.Extra.Severity),
+			File:     r.Path,
+			Line:     r.Start.Line,
+			Message:  r.Extra.Message,
+			CWE:      cwe,
+		})
+	}
+	return findings, nil
+}
+
+func semgrepSeverity(s string) report.Severity {
+	switch strings.ToUpper(s) {
+	case "ERROR":
+		return report.SeverityError
+	case "WARNING":
+		return report.SeverityWarning
+	default:
+		return report.SeverityInfo
+	}
+}
+
+func parseESLintJSON(data []byte) ([]report.Finding, error) {
+	var out []struct {
+		FilePath string `json:"filePath"`
+		Messages []struct {
+			RuleID   string `json:"ruleId"`
+			Severity int    `json:"severity"`
+			Message  string `json:"message"`
+			Line     int    `json:"line"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	var findings []report.Finding
+	for _, file := range out {
+		for _, m := range file.Messages {
+			severity := report.SeverityWarning
+			if m.Severity >= 2 {
+				severity = report.SeverityError
+			}
+			findings = append(findings, report.Finding{
+				Tool:     "eslint",
+				RuleID:   m.RuleID,
+				Severity: severity,
+				File:     file.FilePath,
+				Line:     m.Line,
+				Message:  m.Message,
+			})
+		}
+	}
+	return findings, nil
+}
+
+func parseBanditJSON(data []byte) ([]report.Finding, error) {
+	var out struct {
+		Results []struct {
+			Filename      string `json:"filename"`
+			IssueSeverity string `json:"issue_severity"`
+			IssueText     string `json:"issue_text"`
+			LineNumber    int    `json:"line_number"`
+			TestID        string `json:"test_id"`
+			IssueCwe      struct {
+				ID int `json:"id"`
+			} `json:"issue_cwe"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	var findings []report.Finding
+	for _, r := range out.Results {
+		var cwe string
+		if r.IssueCwe.ID != 0 {
+			cwe = cweID(strconv.Itoa(r.IssueCwe.ID))
+		}
+		findings = append(findings, report.Finding{
+			Tool:     "bandit",
+			RuleID:   r.TestID,
+			Severity: banditSeverity(r.IssueSeverity),
+			File:     r.Filename,
+			Line:     r.LineNumber,
+			Message:  r.IssueText,
+			CWE:      cwe,
+		})
+	}
+	return findings, nil
+}
+
+func banditSeverity(s string) report.Severity {
+	switch strings.ToUpper(s) {
+	case "HIGH", "MEDIUM":
+		return report.SeverityError
+	default:
+		return report.SeverityWarning
+	}
+}
+
+func parseNpmAuditJSON(data []byte) ([]report.Finding, error) {
+	var out struct {
+		Vulnerabilities map[string]struct {
+			Name     string            `json:"name"`
+			Severity string            `json:"severity"`
+			Via      []json.RawMessage `json:"via"`
+		} `json:"vulnerabilities"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	var findings []report.Finding
+	for name, vuln := range out.Vulnerabilities {
+		message := "vulnerable dependency: " + name
+		var ruleID string
+		for _, raw := range vuln.Via {
+			var advisory struct {
+				Title string `json:"title"`
+				URL   string `json:"url"`
+			}
+			if json.Unmarshal(raw, &advisory) == nil && advisory.Title != "" {
+				message = advisory.Title
+				ruleID = advisory.URL
+				break
+			}
+		}
+		findings = append(findings, report.Finding{
+			Tool:     "npm audit",
+			RuleID:   ruleID,
+			Severity: npmSeverity(vuln.Severity),
+			File:     "package.json",
+			Message:  message,
+		})
+	}
+	return findings, nil
+}
+
+func npmSeverity(s string) report.Severity {
+	switch strings.ToLower(s) {
+	case "critical", "high", "moderate":
+		return report.SeverityError
+	default:
+		return report.SeverityWarning
+	}
+}
+
+func parseCargoAuditJSON(data []byte) ([]report.Finding, error) {
+	var out struct {
+		Vulnerabilities struct {
+			List []struct {
+				Advisory struct {
+					ID          string `json:"id"`
+					Title       string `json:"title"`
+					Description string `json:"description"`
+				} `json:"advisory"`
+				Package struct {
+					Name string `json:"name"`
+				} `json:"package"`
+			} `json:"list"`
+		} `json:"vulnerabilities"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	var findings []report.Finding
+	for _, v := range out.Vulnerabilities.List {
+		findings = append(findings, report.Finding{
+			Tool:     "cargo audit",
+			RuleID:   v.Advisory.ID,
+			Severity: report.SeverityError,
+			File:     "Cargo.lock",
+			Message:  v.Advisory.Title + " (" + v.Package.Name + ")",
+		})
+	}
+	return findings, nil
+}
+
+// parseGovulncheckJSON parses govulncheck's "-json" output, a stream of
+// newline-delimited JSON objects rather than a single document.
+func parseGovulncheckJSON(data []byte) ([]report.Finding, error) {
+	var findings []report.Finding
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry struct {
+			OSV *struct {
+				ID      string `json:"id"`
+				Summary string `json:"summary"`
+			} `json:"osv"`
+			Finding *struct {
+				OSV          string `json:"osv"`
+				FixedVersion string `json:"fixed_version"`
+				Trace        []struct {
+					Package  string `json:"package"`
+					Function string `json:"function"`
+				} `json:"trace"`
+			} `json:"finding"`
+		}
+		if err := json.Unmarshal(line, &entry); err != nil {
+			continue
+		}
+
+		if entry.Finding == nil {
+			continue
+		}
+
+		var pkg string
+		if len(entry.Finding.Trace) > 0 {
+			pkg = entry.Finding.Trace[0].Package
+		}
+
+		findings = append(findings, report.Finding{
+			Tool:     "govulncheck",
+			RuleID:   entry.Finding.OSV,
+			Severity: report.SeverityError,
+			File:     "go.mod",
+			Message:  "known vulnerability in " + pkg,
+		})
+	}
+
+	return findings, scanner.Err()
+}
+
+func parseGitleaksJSON(data []byte) ([]report.Finding, error) {
+	var out []struct {
+		Description string `json:"Description"`
+		StartLine   int    `json:"StartLine"`
+		File        string `json:"File"`
+		RuleID      string `json:"RuleID"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	var findings []report.Finding
+	for _, leak := range out {
+		findings = append(findings, report.Finding{
+			Tool:     "gitleaks",
+			RuleID:   leak.RuleID,
+			Severity: report.SeverityError,
+			File:     leak.File,
+			Line:     leak.StartLine,
+			Message:  leak.Description,
+		})
+	}
+	return findings, nil
+}
+
+// firstInt parses the leading integer out of strings like "45" or "45-47",
+// returning 0 if none is found.
+func firstInt(s string) int {
+	end := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+	if end == 0 {
+		return 0
+	}
+	if end == -1 {
+		end = len(s)
+	}
+	n, _ := strconv.Atoi(s[:end])
+	return n
+}
+
+func cweID(id string) string {
+	if id == "" {
+		return ""
+	}
+	return "CWE-" + id
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MaiMarincic/bruh/internal/scantargets"
+	"github.com/MaiMarincic/bruh/pkg/report"
+)
+
+// runBaselineScan is scan's --baseline/--write-baseline/--update-baseline
+// path. A fingerprint-based diff needs structured report.Finding values,
+// not the colored text runMultiLanguageScan prints, so it always goes
+// through collectReport - the same aggregation runReportScan uses for
+// --format=json/sarif - regardless of --format.
+func runBaselineScan(secrets, security, static, vulns bool, forceLanguage, format, output, baselinePath, writeBaselinePath string, updateBaseline bool, targets scantargets.Set) error {
+	if !secrets && !security && !static && !vulns {
+		fmt.Println("No scan types selected. Use --all or enable specific scans.")
+		return nil
+	}
+
+	languages := []string{forceLanguage}
+	if forceLanguage == "" {
+		languages = detectLanguages()
+	}
+	if len(languages) == 0 {
+		fmt.Println("No supported languages detected in current directory")
+		return nil
+	}
+
+	r, err := collectReport(languages, secrets, security, static, vulns, targets)
+	if err != nil {
+		return err
+	}
+
+	if writeBaselinePath != "" {
+		if err := report.NewBaseline(r).Save(writeBaselinePath); err != nil {
+			return fmt.Errorf("failed to write baseline: %v", err)
+		}
+		fmt.Printf("Wrote baseline with %d finding(s) to %s\n", len(r.Findings), writeBaselinePath)
+		return nil
+	}
+
+	old, err := report.LoadBaseline(baselinePath)
+	if err != nil {
+		return fmt.Errorf("failed to read baseline %s: %v", baselinePath, err)
+	}
+
+	if updateBaseline {
+		updated := report.NewBaseline(r).Intersect(old)
+		if err := updated.Save(baselinePath); err != nil {
+			return fmt.Errorf("failed to update baseline: %v", err)
+		}
+		fmt.Printf("Updated baseline %s: %d fingerprint(s) still present\n", baselinePath, len(updated.Fingerprints))
+		return nil
+	}
+
+	fresh := r.NewFindings(old)
+
+	// --format defaults to "text" for the colored-text scan path, which
+	// has no meaning for a finding diff; fall back to json so the new
+	// findings are always serialized as structured data.
+	if format == "" || format == "text" {
+		format = "json"
+	}
+	if err := writeReport(fresh, format, output); err != nil {
+		return err
+	}
+
+	if len(fresh.Findings) > 0 {
+		return fmt.Errorf("%d new finding(s) not present in baseline %s", len(fresh.Findings), baselinePath)
+	}
+	return nil
+}
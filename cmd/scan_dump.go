@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/MaiMarincic/bruh/config"
+	"github.com/MaiMarincic/bruh/internal/gitcmd"
+	"github.com/MaiMarincic/bruh/internal/scantargets"
+)
+
+var scanDumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Bundle a diagnostic archive for reproducing scan findings",
+	Long: `Produce a single .tar.gz containing everything a maintainer needs to
+reproduce a false positive or failing scan without asking the reporter
+for ten follow-up commands: installed tool versions, the effective
+config, detected languages, every scanner's JSON findings, "go env",
+the current git HEAD/status, and OS/arch info.
+
+Attach the resulting archive directly to a bug report, or pipe it
+straight into an upload with --stdout.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		toStdout, _ := cmd.Flags().GetBool("stdout")
+		redact, _ := cmd.Flags().GetBool("redact")
+
+		archive, err := buildDumpArchive(redact)
+		if err != nil {
+			return fmt.Errorf("failed to build diagnostic bundle: %v", err)
+		}
+
+		if toStdout {
+			_, err := os.Stdout.Write(archive)
+			return err
+		}
+
+		name := fmt.Sprintf("bruh-scan-dump-%s.tar.gz", time.Now().UTC().Format("20060102-150405"))
+		if err := os.WriteFile(name, archive, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %v", name, err)
+		}
+		fmt.Printf("Wrote diagnostic bundle to %s\n", name)
+		return nil
+	},
+}
+
+func init() {
+	scanDumpCmd.Flags().Bool("stdout", false, "Stream the archive to stdout instead of writing a file")
+	scanDumpCmd.Flags().Bool("redact", false, "Scrub absolute paths and tokens from the bundled files")
+}
+
+// buildDumpArchive collects every diagnostic file bruh scan dump bundles
+// and returns the finished .tar.gz.
+func buildDumpArchive(redact bool) ([]byte, error) {
+	files := map[string][]byte{
+		"os-arch.txt":       []byte(fmt.Sprintf("%s/%s\n", runtime.GOOS, runtime.GOARCH)),
+		"tool-versions.txt": dumpToolVersions(),
+		"go-env.txt":        dumpGoEnv(),
+		"git-status.txt":    dumpGitStatus(),
+	}
+
+	languages := detectLanguages()
+	files["languages.txt"] = []byte(strings.Join(languages, "\n") + "\n")
+
+	if cfgData, err := dumpConfig(); err == nil {
+		files["config.yaml"] = cfgData
+	}
+
+	if len(languages) > 0 {
+		if r, err := collectReport(languages, true, true, true, true, scantargets.Default()); err == nil {
+			if data, err := r.ToJSON(); err == nil {
+				files["findings.json"] = data
+			}
+		}
+	}
+
+	if redact {
+		for name, data := range files {
+			files[name] = redactBytes(data)
+		}
+	}
+
+	return tarGzip(files)
+}
+
+// versionCommands lists every tool bruh scan knows how to invoke, paired
+// with the flag that prints its version.
+var versionCommands = []struct {
+	tool string
+	args []string
+}{
+	{"go", []string{"version"}},
+	{"gosec", []string{"--version"}},
+	{"staticcheck", []string{"-version"}},
+	{"govulncheck", []string{"-version"}},
+	{"gitleaks", []string{"version"}},
+	{"semgrep", []string{"--version"}},
+	{"eslint", []string{"-v"}},
+	{"npm", []string{"--version"}},
+	{"bandit", []string{"--version"}},
+	{"pylint", []string{"--version"}},
+	{"safety", []string{"--version"}},
+	{"spotbugs", []string{"-version"}},
+	{"pmd", []string{"--version"}},
+	{"cargo", []string{"--version"}},
+	{"cargo-audit", []string{"--version"}},
+}
+
+func dumpToolVersions() []byte {
+	var b bytes.Buffer
+	for _, vc := range versionCommands {
+		if !isToolInstalled(vc.tool) {
+			fmt.Fprintf(&b, "%s: not installed\n", vc.tool)
+			continue
+		}
+
+		out, err := exec.Command(vc.tool, vc.args...).CombinedOutput()
+		if err != nil && len(out) == 0 {
+			fmt.Fprintf(&b, "%s: failed to run %v: %v\n", vc.tool, vc.args, err)
+			continue
+		}
+		fmt.Fprintf(&b, "%s: %s\n", vc.tool, strings.TrimSpace(string(out)))
+	}
+	return b.Bytes()
+}
+
+func dumpGoEnv() []byte {
+	out, err := exec.Command("go", "env").CombinedOutput()
+	if err != nil {
+		return []byte(fmt.Sprintf("failed to run go env: %v\n", err))
+	}
+	return out
+}
+
+func dumpGitStatus() []byte {
+	var b bytes.Buffer
+	ctx := context.Background()
+
+	head, _, err := gitcmd.New(ctx, "rev-parse").AddArguments("HEAD").RunStdString(nil)
+	if err != nil {
+		fmt.Fprintf(&b, "git rev-parse HEAD failed: %v\n", err)
+	} else {
+		fmt.Fprintf(&b, "HEAD: %s", head)
+	}
+
+	status, _, err := gitcmd.New(ctx, "status").AddArguments("--porcelain").RunStdString(nil)
+	if err != nil {
+		fmt.Fprintf(&b, "git status --porcelain failed: %v\n", err)
+	} else {
+		b.WriteString("status --porcelain:\n")
+		b.WriteString(status)
+	}
+
+	return b.Bytes()
+}
+
+func dumpConfig() ([]byte, error) {
+	return yaml.Marshal(config.Get())
+}
+
+var (
+	secretAssignment = regexp.MustCompile(`(?i)([a-z_]*(?:token|secret|password|api_key|apikey)[a-z_]*\s*[:=]\s*)\S+`)
+	urlCredentials   = regexp.MustCompile(`([a-zA-Z][a-zA-Z0-9+.-]*://)[^/\s@]+@`)
+)
+
+// redactBytes scrubs the current user's home directory and working
+// directory out of data, plus anything that looks like a token/secret
+// assignment or credentials embedded in a URL.
+func redactBytes(data []byte) []byte {
+	text := string(data)
+
+	if home, err := os.UserHomeDir(); err == nil && home != "" {
+		text = strings.ReplaceAll(text, home, "$HOME")
+	}
+	if cwd, err := os.Getwd(); err == nil && cwd != "" {
+		text = strings.ReplaceAll(text, cwd, "$PWD")
+	}
+
+	text = secretAssignment.ReplaceAllString(text, "${1}REDACTED")
+	text = urlCredentials.ReplaceAllString(text, "${1}REDACTED@")
+
+	return []byte(text)
+}
+
+// tarGzip writes files into a gzip-compressed tar archive, in a
+// deterministic (sorted) order.
+func tarGzip(files map[string][]byte) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		data := files[name]
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(data))}); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/MaiMarincic/bruh/config"
+	"github.com/MaiMarincic/bruh/internal/scantargets"
+	"github.com/MaiMarincic/bruh/pkg/report"
+)
+
+// runCreateIssuesScan is scan's --create-issues path: like
+// runBaselineScan, it needs structured report.Finding values to
+// fingerprint and dedupe against, so it always goes through
+// collectReport regardless of --format.
+func runCreateIssuesScan(secrets, security, static, vulns bool, forceLanguage, format, output string, targets scantargets.Set) error {
+	if !secrets && !security && !static && !vulns {
+		fmt.Println("No scan types selected. Use --all or enable specific scans.")
+		return nil
+	}
+
+	languages := []string{forceLanguage}
+	if forceLanguage == "" {
+		languages = detectLanguages()
+	}
+	if len(languages) == 0 {
+		fmt.Println("No supported languages detected in current directory")
+		return nil
+	}
+
+	r, err := collectReport(languages, secrets, security, static, vulns, targets)
+	if err != nil {
+		return err
+	}
+
+	if err := createIssuesForFindings(r); err != nil {
+		return err
+	}
+
+	if format == "" || format == "text" {
+		format = "json"
+	}
+	if err := writeReport(r, format, output); err != nil {
+		return err
+	}
+
+	if r.HasErrors() {
+		return fmt.Errorf("scan found %d finding(s)", len(r.Findings))
+	}
+	return nil
+}
+
+// createIssuesForFindings files one GitHub issue per unique
+// SeverityError finding in r, reusing an existing open issue (matched by
+// the finding's fingerprint, the same one --baseline uses, embedded as
+// an HTML comment in the issue body) instead of filing a duplicate.
+func createIssuesForFindings(r report.Report) error {
+	if err := checkGHCLI(); err != nil {
+		return fmt.Errorf("GitHub CLI (gh) is not installed or not authenticated: %w", err)
+	}
+
+	var repoArgs []string
+	if repo := config.Get().PR.IssueRepo; repo != "" {
+		repoArgs = []string{"--repo", repo}
+	}
+
+	seen := map[string]bool{}
+	for _, f := range r.Findings {
+		if f.Severity != report.SeverityError {
+			continue
+		}
+
+		fp := f.Fingerprint()
+		if seen[fp] {
+			continue
+		}
+		seen[fp] = true
+
+		if err := createOrUpdateIssue(f, fp, repoArgs); err != nil {
+			return fmt.Errorf("failed to file issue for %s %s: %v", f.Tool, f.RuleID, err)
+		}
+	}
+	return nil
+}
+
+func createOrUpdateIssue(f report.Finding, fingerprint string, repoArgs []string) error {
+	number, err := findExistingIssue(fingerprint, repoArgs)
+	if err != nil {
+		return err
+	}
+
+	body := issueBody(f, fingerprint)
+
+	if number != 0 {
+		args := append([]string{"issue", "edit", fmt.Sprintf("%d", number), "--body", body}, repoArgs...)
+		return runGH(args...)
+	}
+
+	args := append([]string{"issue", "create", "--title", issueTitle(f), "--body", body}, repoArgs...)
+	return runGH(args...)
+}
+
+func issueTitle(f report.Finding) string {
+	return fmt.Sprintf("[bruh/%s] %s: %s (%s:%d)", f.Tool, f.RuleID, f.Message, f.File, f.Line)
+}
+
+func issueBody(f report.Finding, fingerprint string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s\n\n", fingerprintComment(fingerprint))
+	fmt.Fprintf(&b, "**Tool:** %s\n**Rule:** %s\n**Location:** `%s:%d`\n\n", f.Tool, f.RuleID, f.File, f.Line)
+	fmt.Fprintf(&b, "%s\n\n", f.Message)
+
+	if excerpt := codeExcerpt(f.File, f.Line); excerpt != "" {
+		fmt.Fprintf(&b, "```\n%s\n```\n\n", excerpt)
+	}
+
+	if f.CWE != "" {
+		id := strings.TrimPrefix(f.CWE, "CWE-")
+		fmt.Fprintf(&b, "See https://cwe.mitre.org/data/definitions/%s.html for remediation guidance.\n", id)
+	}
+
+	return b.String()
+}
+
+// fingerprintComment wraps fingerprint in an HTML comment so it's
+// invisible when the issue renders but still searchable via `gh issue
+// list --search`.
+func fingerprintComment(fingerprint string) string {
+	return fmt.Sprintf("<!-- bruh-fingerprint: %s -->", fingerprint)
+}
+
+// codeExcerpt returns the trimmed text of file's line, or "" if it can't
+// be read.
+func codeExcerpt(file string, line int) string {
+	if file == "" || line <= 0 {
+		return ""
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		if n == line {
+			return strings.TrimSpace(scanner.Text())
+		}
+	}
+	return ""
+}
+
+// findExistingIssue searches open issues for fingerprint's marker,
+// returning its number, or 0 if none is found.
+func findExistingIssue(fingerprint string, repoArgs []string) (int, error) {
+	args := append([]string{"issue", "list", "--state", "open", "--search", fingerprintComment(fingerprint), "--json", "number,body"}, repoArgs...)
+
+	out, err := ghOutput(args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to search existing issues: %v", err)
+	}
+
+	var issues []struct {
+		Number int    `json:"number"`
+		Body   string `json:"body"`
+	}
+	if err := json.Unmarshal(out, &issues); err != nil {
+		return 0, fmt.Errorf("failed to parse gh issue list output: %v", err)
+	}
+
+	marker := fingerprintComment(fingerprint)
+	for _, issue := range issues {
+		if strings.Contains(issue.Body, marker) {
+			return issue.Number, nil
+		}
+	}
+	return 0, nil
+}
+
+// runGH runs a gh subcommand that only needs its exit status checked.
+func runGH(args ...string) error {
+	_, err := ghOutput(args...)
+	return err
+}
+
+// ghOutput runs a gh subcommand and returns its stdout, honoring
+// config.Config's pr.github_token override.
+func ghOutput(args ...string) ([]byte, error) {
+	cmd := exec.Command("gh", args...)
+	if token := config.Get().PR.GithubToken; token != "" {
+		cmd.Env = append(os.Environ(), "GH_TOKEN="+token)
+	}
+
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("%v: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+	return output, nil
+}
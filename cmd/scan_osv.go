@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/MaiMarincic/bruh/config"
+	"github.com/MaiMarincic/bruh/internal/osv"
+	"github.com/MaiMarincic/bruh/pkg/report"
+)
+
+// osvManifests lists every manifest/lockfile bruh knows how to turn into
+// osv.Package tuples, grouped so that a lockfile is preferred over its
+// looser manifest when both are present (poetry.lock over
+// requirements.txt) instead of querying the same dependency twice.
+var osvManifests = []struct {
+	group string
+	file  string
+	parse func([]byte) ([]osv.Package, error)
+}{
+	{"python", "poetry.lock", osv.ParsePoetryLock},
+	{"python", "requirements.txt", osv.ParseRequirementsTxt},
+	{"javascript", "package.json", osv.ParsePackageJSON},
+	{"rust", "Cargo.lock", osv.ParseCargoLock},
+}
+
+// collectOSVFindings queries OSV.dev for every manifest/lockfile bruh
+// finds in the current directory and turns the results into
+// report.Findings, merged into the same stream collectGoFindings'
+// govulncheck findings go into.
+func collectOSVFindings() ([]report.Finding, error) {
+	var pkgs []osv.Package
+	seenGroup := map[string]bool{}
+
+	for _, m := range osvManifests {
+		if seenGroup[m.group] {
+			continue
+		}
+		data, err := os.ReadFile(m.file)
+		if err != nil {
+			continue
+		}
+		parsed, err := m.parse(data)
+		if err != nil {
+			continue
+		}
+		pkgs = append(pkgs, parsed...)
+		seenGroup[m.group] = true
+	}
+
+	if len(pkgs) == 0 {
+		return nil, nil
+	}
+
+	client := osv.NewClient(osv.NewCache(filepath.Join(config.CacheDir(), "osv"), osvCacheTTL()))
+
+	results, err := client.Query(context.Background(), pkgs)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []report.Finding
+	for pkg, vulns := range results {
+		for _, v := range vulns {
+			findings = append(findings, report.Finding{
+				Tool:     "osv",
+				RuleID:   v.ID,
+				Severity: report.SeverityError,
+				File:     manifestFileFor(pkg.Ecosystem),
+				Message:  osvMessage(pkg, v),
+			})
+		}
+	}
+	return findings, nil
+}
+
+func osvCacheTTL() time.Duration {
+	const fallback = 24 * time.Hour
+
+	ttl, err := time.ParseDuration(config.Get().Scan.OSVCacheTTL)
+	if err != nil {
+		return fallback
+	}
+	return ttl
+}
+
+func manifestFileFor(ecosystem string) string {
+	switch ecosystem {
+	case "npm":
+		return "package.json"
+	case "PyPI":
+		return "requirements.txt"
+	case "crates.io":
+		return "Cargo.lock"
+	default:
+		return ""
+	}
+}
+
+func osvMessage(pkg osv.Package, v osv.Vuln) string {
+	if v.Summary != "" {
+		return v.Summary + " (" + pkg.Name + "@" + pkg.Version + ")"
+	}
+	return "known vulnerability in " + pkg.Name + "@" + pkg.Version
+}
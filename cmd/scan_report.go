@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/MaiMarincic/bruh/internal/govuln"
+	"github.com/MaiMarincic/bruh/internal/scantargets"
+	"github.com/MaiMarincic/bruh/pkg/report"
+)
+
+// runJSONTool invokes checkBin (to confirm it's installed) as execBin with
+// args and parses its stdout with parse, regardless of the tool's own exit
+// code: most of these tools exit non-zero precisely when they found
+// something to report, so only genuinely malformed output is treated as a
+// failure. If checkBin isn't installed, the tool is skipped and contributes
+// no findings.
+func runJSONTool(checkBin, execBin string, args []string, parse func([]byte) ([]report.Finding, error)) ([]report.Finding, error) {
+	if !isToolInstalled(checkBin) {
+		return nil, nil
+	}
+
+	cmd := exec.Command(execBin, args...)
+	output, runErr := cmd.CombinedOutput()
+
+	findings, parseErr := parse(output)
+	if parseErr != nil {
+		if runErr != nil {
+			return nil, fmt.Errorf("failed to run %s: %v", execBin, runErr)
+		}
+		return nil, fmt.Errorf("failed to parse %s output: %v", execBin, parseErr)
+	}
+
+	return findings, nil
+}
+
+func collectGoFindings(security, static, vulns bool, targets scantargets.Set) ([]report.Finding, error) {
+	var findings []report.Finding
+
+	if security {
+		f, err := runJSONTool("gosec", "gosec", append([]string{"-fmt", "json"}, targets.GoPackages...), parseGosecJSON)
+		if err != nil {
+			return findings, err
+		}
+		findings = append(findings, f...)
+	}
+
+	if static {
+		f, err := runJSONTool("staticcheck", "staticcheck", append([]string{"-f", "json"}, targets.GoPackages...), parseStaticcheckJSON)
+		if err != nil {
+			return findings, err
+		}
+		findings = append(findings, f...)
+	}
+
+	if vulns {
+		output, err := govuln.Run(context.Background(), targets.GoPackages...)
+		if err != nil {
+			return findings, fmt.Errorf("failed to run govulncheck: %v", err)
+		}
+		f, err := parseGovulncheckJSON(output)
+		if err != nil {
+			return findings, err
+		}
+		findings = append(findings, f...)
+	}
+
+	return findings, nil
+}
+
+func collectJavaScriptFindings(static, vulns bool, targets scantargets.Set) ([]report.Finding, error) {
+	var findings []report.Finding
+
+	if static {
+		args := append(append([]string{}, targets.Dirs...), "--ext", ".js,.jsx,.ts,.tsx", "-f", "json")
+		f, err := runJSONTool("eslint", "eslint", args, parseESLintJSON)
+		if err != nil {
+			return findings, err
+		}
+		findings = append(findings, f...)
+	}
+
+	if vulns {
+		f, err := runJSONTool("npm", "npm", []string{"audit", "--json"}, parseNpmAuditJSON)
+		if err != nil {
+			return findings, err
+		}
+		findings = append(findings, f...)
+	}
+
+	return findings, nil
+}
+
+func collectPythonFindings(security bool, targets scantargets.Set) ([]report.Finding, error) {
+	if !security {
+		return nil, nil
+	}
+	args := append([]string{"-r"}, targets.Dirs...)
+	args = append(args, "-f", "json")
+	return runJSONTool("bandit", "bandit", args, parseBanditJSON)
+}
+
+func collectRustFindings(vulns bool) ([]report.Finding, error) {
+	if !vulns {
+		return nil, nil
+	}
+	return runJSONTool("cargo-audit", "cargo", []string{"audit", "--json"}, parseCargoAuditJSON)
+}
+
+func collectSecretFindings() ([]report.Finding, error) {
+	return runJSONTool("gitleaks", "gitleaks", []string{"detect", "--report-format", "json", "--report-path", "/dev/stdout", "--exit-code", "0"}, parseGitleaksJSON)
+}
+
+func collectSemgrepFindings(targets scantargets.Set) ([]report.Finding, error) {
+	args := append([]string{"--json", "--quiet"}, targets.Dirs...)
+	return runJSONTool("semgrep", "semgrep", args, parseSemgrepJSON)
+}
+
+// collectReport runs every enabled scanner across the detected languages in
+// JSON-output mode and aggregates their findings into a single report,
+// instead of printing colored text as runMultiLanguageScan does. targets
+// scopes the path-aware tools the same way buildScanners does.
+func collectReport(languages []string, secrets, security, static, vulns bool, targets scantargets.Set) (report.Report, error) {
+	var r report.Report
+
+	if secrets {
+		f, err := collectSecretFindings()
+		if err != nil {
+			return r, err
+		}
+		r.Findings = append(r.Findings, f...)
+	}
+
+	if static {
+		f, err := collectSemgrepFindings(targets)
+		if err != nil {
+			return r, err
+		}
+		r.Findings = append(r.Findings, f...)
+	}
+
+	for _, lang := range languages {
+		var (
+			f   []report.Finding
+			err error
+		)
+		switch lang {
+		case "go":
+			f, err = collectGoFindings(security, static, vulns, targets)
+		case "javascript", "typescript":
+			f, err = collectJavaScriptFindings(static, vulns, targets)
+		case "python":
+			f, err = collectPythonFindings(security, targets)
+		case "rust":
+			f, err = collectRustFindings(vulns)
+		}
+		if err != nil {
+			return r, err
+		}
+		r.Findings = append(r.Findings, f...)
+	}
+
+	if vulns {
+		f, err := collectOSVFindings()
+		if err != nil {
+			return r, err
+		}
+		r.Findings = append(r.Findings, f...)
+	}
+
+	return r, nil
+}
+
+// writeReport serializes r in format ("json" or "sarif") and writes it to
+// path, or stdout if path is empty.
+func writeReport(r report.Report, format, path string) error {
+	var data []byte
+	var err error
+
+	switch format {
+	case "sarif":
+		data, err = r.ToSARIF()
+	default:
+		data, err = r.ToJSON()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to serialize report: %v", err)
+	}
+
+	if path == "" {
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write report to %s: %v", path, err)
+	}
+	return nil
+}
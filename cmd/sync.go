@@ -0,0 +1,404 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/MaiMarincic/bruh/internal/gitcmd"
+	"github.com/MaiMarincic/bruh/internal/syncsources"
+	"github.com/MaiMarincic/bruh/pkg/cheatsheet"
+	"github.com/spf13/cobra"
+)
+
+// sourcesDir returns the directory sources are cloned into: a ".sources"
+// directory inside the active cheat directory.
+func sourcesDir(cheatDir string) string {
+	return filepath.Join(cheatDir, ".sources")
+}
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync navi cheat sheets from remote git repositories",
+	Long: `Manages remote cheat sheet repositories, so teams can share curated navi cheats via git without leaving bruh.
+
+Each source is git-cloned into <cheat-directory>/.sources/<name> and its cheat sheets are merged into the active cheat directory. Tag sets that collide with an already-present one are resolved with --strategy (prefer-local, prefer-remote, or namespace, which rewrites the incoming tags to "<name>:tag").`,
+}
+
+var syncAddCmd = &cobra.Command{
+	Use:   "add <git-url>",
+	Short: "Clone a remote cheat sheet repository and merge it into the cheat directory",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSyncAdd,
+}
+
+var syncListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured cheat sheet sources",
+	RunE:  runSyncList,
+}
+
+var syncUpdateCmd = &cobra.Command{
+	Use:   "update [name...]",
+	Short: "Pull and re-merge one or all configured sources",
+	RunE:  runSyncUpdate,
+}
+
+var syncRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Forget a configured source and delete its clone",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSyncRemove,
+}
+
+var syncSearchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search descriptions and commands across all synced sources",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSyncSearch,
+}
+
+func init() {
+	syncCmd.PersistentFlags().StringP("cheat-directory", "d", defaultCheatDir(), "Directory containing navi cheat sheets")
+
+	syncAddCmd.Flags().String("subdir", "", "Only merge cheat sheets under this subdirectory of the cloned repository")
+	syncAddCmd.Flags().String("as", "", "Name to register the source under (default: derived from the repository URL)")
+	syncAddCmd.Flags().String("strategy", "namespace", "Conflict strategy for colliding tag sets: prefer-local, prefer-remote, or namespace")
+
+	syncCmd.AddCommand(syncAddCmd, syncListCmd, syncUpdateCmd, syncRemoveCmd, syncSearchCmd)
+	rootCmd.AddCommand(syncCmd)
+}
+
+func runSyncAdd(cmd *cobra.Command, args []string) error {
+	url := args[0]
+	subdir, _ := cmd.Flags().GetString("subdir")
+	name, _ := cmd.Flags().GetString("as")
+	strategy, _ := cmd.Flags().GetString("strategy")
+	if name == "" {
+		name = deriveSourceName(url)
+	}
+
+	cheatDir, err := absCheatDirFlag(cmd)
+	if err != nil {
+		return err
+	}
+
+	state, err := syncsources.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load sources: %v", err)
+	}
+
+	if _, exists := state.Get(name); exists {
+		return fmt.Errorf("source %q is already configured; use 'bruh sync update %s' instead", name, name)
+	}
+
+	src := syncsources.Source{Name: name, URL: url, Subdir: subdir, Strategy: strategy}
+
+	cloneDir := filepath.Join(sourcesDir(cheatDir), name)
+	if _, err := gitcmd.New(context.Background(), "clone").
+		AddDynamicArguments(url, cloneDir).
+		CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to clone %s: %v", url, err)
+	}
+
+	if err := state.Add(src); err != nil {
+		return fmt.Errorf("failed to save source %q: %v", name, err)
+	}
+
+	merged, skipped, err := mergeSource(cheatDir, src)
+	if err != nil {
+		return fmt.Errorf("failed to merge source %q: %v", name, err)
+	}
+
+	fmt.Printf("Added source %q (%s): merged %d sheet(s)", name, url, merged)
+	if skipped > 0 {
+		fmt.Printf(", skipped %d due to conflicts", skipped)
+	}
+	fmt.Println()
+	return nil
+}
+
+func runSyncList(cmd *cobra.Command, args []string) error {
+	state, err := syncsources.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load sources: %v", err)
+	}
+
+	if len(state.Sources) == 0 {
+		fmt.Println("No sources configured. Add one with 'bruh sync add <git-url>'.")
+		return nil
+	}
+
+	for _, src := range state.Sources {
+		fmt.Printf("%s\t%s", src.Name, src.URL)
+		if src.Subdir != "" {
+			fmt.Printf(" (subdir: %s)", src.Subdir)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+func runSyncUpdate(cmd *cobra.Command, args []string) error {
+	cheatDir, err := absCheatDirFlag(cmd)
+	if err != nil {
+		return err
+	}
+
+	state, err := syncsources.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load sources: %v", err)
+	}
+
+	targets := state.Sources
+	if len(args) > 0 {
+		targets = nil
+		for _, name := range args {
+			src, ok := state.Get(name)
+			if !ok {
+				return fmt.Errorf("no source named %q is configured", name)
+			}
+			targets = append(targets, src)
+		}
+	}
+
+	for _, src := range targets {
+		cloneDir := filepath.Join(sourcesDir(cheatDir), src.Name)
+		if _, _, err := gitcmd.New(context.Background(), "pull").
+			RunStdString(&gitcmd.RunOpts{Dir: cloneDir}); err != nil {
+			return fmt.Errorf("failed to update source %q: %v", src.Name, err)
+		}
+
+		merged, skipped, err := mergeSource(cheatDir, src)
+		if err != nil {
+			return fmt.Errorf("failed to merge source %q: %v", src.Name, err)
+		}
+
+		fmt.Printf("Updated %q: merged %d sheet(s)", src.Name, merged)
+		if skipped > 0 {
+			fmt.Printf(", skipped %d due to conflicts", skipped)
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
+
+func runSyncRemove(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cheatDir, err := absCheatDirFlag(cmd)
+	if err != nil {
+		return err
+	}
+
+	state, err := syncsources.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load sources: %v", err)
+	}
+
+	removed, err := state.Remove(name)
+	if err != nil {
+		return fmt.Errorf("failed to update sources: %v", err)
+	}
+	if !removed {
+		return fmt.Errorf("no source named %q is configured", name)
+	}
+
+	cloneDir := filepath.Join(sourcesDir(cheatDir), name)
+	if err := os.RemoveAll(cloneDir); err != nil {
+		return fmt.Errorf("failed to delete clone for %q: %v", name, err)
+	}
+
+	fmt.Printf("Removed source %q. Previously merged sheets were left in place.\n", name)
+	return nil
+}
+
+func runSyncSearch(cmd *cobra.Command, args []string) error {
+	query := strings.ToLower(args[0])
+
+	cheatDir, err := absCheatDirFlag(cmd)
+	if err != nil {
+		return err
+	}
+
+	state, err := syncsources.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load sources: %v", err)
+	}
+
+	found := false
+	for _, src := range state.Sources {
+		cloneDir := filepath.Join(sourcesDir(cheatDir), src.Name)
+		sheets, err := cheatsheet.Load(mergeRoot(cloneDir, src.Subdir))
+		if err != nil {
+			continue
+		}
+
+		for _, sheet := range sheets {
+			for _, e := range sheet.Entries {
+				haystack := strings.ToLower(e.Description + "\n" + e.Command)
+				if !strings.Contains(haystack, query) {
+					continue
+				}
+				found = true
+				fmt.Printf("[%s] %s\n    %s\n", src.Name, e.Description, strings.ReplaceAll(e.Command, "\n", "\n    "))
+			}
+		}
+	}
+
+	if !found {
+		fmt.Println("No matches found.")
+	}
+	return nil
+}
+
+func absCheatDirFlag(cmd *cobra.Command) (string, error) {
+	cheatDir, _ := cmd.Flags().GetString("cheat-directory")
+	absCheatDir, err := filepath.Abs(cheatDir)
+	if err != nil {
+		return "", fmt.Errorf("invalid cheat directory path: %v", err)
+	}
+	return absCheatDir, nil
+}
+
+// deriveSourceName turns a git URL into a short name, e.g.
+// "https://github.com/denisidoro/cheats.git" -> "cheats".
+func deriveSourceName(url string) string {
+	name := strings.TrimSuffix(strings.TrimSuffix(url, "/"), ".git")
+	if i := strings.LastIndexAny(name, "/:"); i != -1 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+func mergeRoot(cloneDir, subdir string) string {
+	if subdir == "" {
+		return cloneDir
+	}
+	return filepath.Join(cloneDir, subdir)
+}
+
+// mergeSource copies src's cheat sheets from its clone into cheatDir,
+// applying src.Strategy whenever an incoming tag set collides with one
+// that already exists in cheatDir. It returns how many sheets were merged
+// and how many were skipped outright (prefer-local conflicts).
+func mergeSource(cheatDir string, src syncsources.Source) (merged, skipped int, err error) {
+	cloneDir := filepath.Join(sourcesDir(cheatDir), src.Name)
+
+	incoming, err := cheatsheet.Load(mergeRoot(cloneDir, src.Subdir))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	existing, err := cheatsheet.Load(cheatDir)
+	if err != nil {
+		return 0, 0, err
+	}
+	existingTagSets := collectTagSets(existing)
+	tagSetOwners := collectTagSetOwners(existing)
+	removedOwners := map[string]bool{}
+
+	for _, sheet := range incoming {
+		targetPath := filepath.Join(cheatDir, filepath.Base(sheet.Path))
+		entries := sheet.Entries
+		conflict := sheetConflicts(entries, existingTagSets)
+
+		if conflict {
+			switch src.Strategy {
+			case "prefer-local":
+				skipped++
+				continue
+			case "prefer-remote":
+				// The remote wins outright, so the local sheet that
+				// currently owns the colliding tag set must go - otherwise
+				// the tag set ends up defined in both files.
+				for _, e := range entries {
+					owner, ok := tagSetOwners[tagSetKey(e.Tags)]
+					if !ok || owner == targetPath || removedOwners[owner] {
+						continue
+					}
+					if err := os.Remove(owner); err != nil && !os.IsNotExist(err) {
+						return merged, skipped, err
+					}
+					removedOwners[owner] = true
+				}
+			default: // "namespace"
+				entries = namespaceEntries(entries, src.Name, existingTagSets)
+				targetPath = filepath.Join(cheatDir, src.Name+"-"+filepath.Base(sheet.Path))
+			}
+		}
+
+		out := cheatsheet.New(targetPath)
+		for _, e := range entries {
+			out.AddEntry(e)
+		}
+		if err := out.Save(); err != nil {
+			return merged, skipped, err
+		}
+		merged++
+	}
+
+	return merged, skipped, nil
+}
+
+func collectTagSets(sheets []*cheatsheet.Sheet) map[string]bool {
+	sets := map[string]bool{}
+	for _, sheet := range sheets {
+		for _, e := range sheet.Entries {
+			sets[tagSetKey(e.Tags)] = true
+		}
+	}
+	return sets
+}
+
+// collectTagSetOwners maps each tag set already present in sheets to the
+// path of the sheet that defines it, so a conflicting incoming sheet can
+// find and remove it under the "prefer-remote" strategy.
+func collectTagSetOwners(sheets []*cheatsheet.Sheet) map[string]string {
+	owners := map[string]string{}
+	for _, sheet := range sheets {
+		for _, e := range sheet.Entries {
+			owners[tagSetKey(e.Tags)] = sheet.Path
+		}
+	}
+	return owners
+}
+
+func sheetConflicts(entries []cheatsheet.Entry, existingTagSets map[string]bool) bool {
+	for _, e := range entries {
+		if existingTagSets[tagSetKey(e.Tags)] {
+			return true
+		}
+	}
+	return false
+}
+
+// namespaceEntries rewrites the tags of every entry whose tag set collides
+// with an existing one to "<name>:tag", leaving non-colliding entries
+// untouched.
+func namespaceEntries(entries []cheatsheet.Entry, name string, existingTagSets map[string]bool) []cheatsheet.Entry {
+	out := make([]cheatsheet.Entry, len(entries))
+	for i, e := range entries {
+		out[i] = e
+		if !existingTagSets[tagSetKey(e.Tags)] {
+			continue
+		}
+		namespaced := make([]string, len(e.Tags))
+		for j, t := range e.Tags {
+			namespaced[j] = name + ":" + t
+		}
+		out[i].Tags = namespaced
+	}
+	return out
+}
+
+func tagSetKey(tags []string) string {
+	normalized := make([]string, len(tags))
+	copy(normalized, tags)
+	sort.Strings(normalized)
+	return strings.ToLower(strings.Join(normalized, ","))
+}
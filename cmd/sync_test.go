@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/MaiMarincic/bruh/pkg/cheatsheet"
+)
+
+func TestDeriveSourceName(t *testing.T) {
+	cases := map[string]string{
+		"https://github.com/denisidoro/cheats.git": "cheats",
+		"https://github.com/denisidoro/cheats":     "cheats",
+		"git@github.com:denisidoro/cheats.git":     "cheats",
+	}
+	for url, want := range cases {
+		if got := deriveSourceName(url); got != want {
+			t.Errorf("deriveSourceName(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
+
+func TestSheetConflicts(t *testing.T) {
+	existing := map[string]bool{tagSetKey([]string{"git", "code"}): true}
+
+	entries := []cheatsheet.Entry{{Tags: []string{"git", "code"}}}
+	if !sheetConflicts(entries, existing) {
+		t.Error("expected a conflict for a matching tag set")
+	}
+
+	entries = []cheatsheet.Entry{{Tags: []string{"docker"}}}
+	if sheetConflicts(entries, existing) {
+		t.Error("expected no conflict for a non-matching tag set")
+	}
+}
+
+func TestNamespaceEntries_OnlyRewritesCollidingEntries(t *testing.T) {
+	existing := map[string]bool{tagSetKey([]string{"git", "code"}): true}
+	entries := []cheatsheet.Entry{
+		{Tags: []string{"git", "code"}, Description: "colliding"},
+		{Tags: []string{"docker"}, Description: "unique"},
+	}
+
+	got := namespaceEntries(entries, "upstream", existing)
+
+	want := []string{"upstream:git", "upstream:code"}
+	if !reflect.DeepEqual(got[0].Tags, want) {
+		t.Errorf("colliding entry Tags = %v, want %v", got[0].Tags, want)
+	}
+	if !reflect.DeepEqual(got[1].Tags, []string{"docker"}) {
+		t.Errorf("non-colliding entry Tags = %v, want unchanged", got[1].Tags)
+	}
+}
@@ -0,0 +1,354 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/MaiMarincic/bruh/config"
+	"github.com/MaiMarincic/bruh/internal/errs"
+	"github.com/MaiMarincic/bruh/internal/gitcmd"
+	"github.com/MaiMarincic/bruh/internal/wtstate"
+	"github.com/MaiMarincic/bruh/pkg/git"
+	"github.com/spf13/cobra"
+)
+
+// BranchRuntime holds the resolved options for creating a worktree and
+// opening an editor in it.
+type BranchRuntime struct {
+	UsingTmux  bool
+	FromBranch string
+	BranchName string
+	Editor     string
+}
+
+var worktreeCmd = &cobra.Command{
+	Use:   "worktree",
+	Short: "Manage git worktrees created by bruh",
+}
+
+var worktreeNewCmd = &cobra.Command{
+	Use:   "new",
+	Short: "Create a git worktree and open editor",
+	RunE:  runWorktreeNew,
+}
+
+var worktreeLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List worktrees and their merge/remote status",
+	RunE:  runWorktreeLs,
+}
+
+var worktreeRmCmd = &cobra.Command{
+	Use:   "rm <name|path>",
+	Short: "Remove a worktree",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runWorktreeRm,
+}
+
+var worktreePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove bruh-created worktrees that are merged or whose upstream branch was deleted",
+	RunE:  runWorktreePrune,
+}
+
+var worktreeCdCmd = &cobra.Command{
+	Use:   "cd <name>",
+	Short: "Print the path of a worktree, or switch to its tmux window",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runWorktreeCd,
+}
+
+func init() {
+	registerNewFlags(worktreeNewCmd)
+
+	worktreeRmCmd.Flags().Bool("force", false, "Force removal even if the worktree has local changes")
+	worktreeRmCmd.Flags().Bool("delete-branch", false, "Also delete the local branch")
+
+	worktreePruneCmd.Flags().Bool("dry-run", false, "Print what would be removed without removing it")
+
+	worktreeCdCmd.Flags().Bool("tmux", false, "Switch to an existing tmux window for this worktree instead of printing its path")
+
+	worktreeCmd.AddCommand(worktreeNewCmd, worktreeLsCmd, worktreeRmCmd, worktreePruneCmd, worktreeCdCmd)
+	rootCmd.AddCommand(worktreeCmd)
+}
+
+// registerNewFlags registers the flags shared by `bruh branch` and
+// `bruh worktree new`, which are the same command under two names.
+func registerNewFlags(c *cobra.Command) {
+	c.Flags().Bool("using-tmux", false, "Use tmux for editor session (default from config)")
+	c.Flags().String("from-branch", "", "Branch from which to create worktree (default: current branch)")
+	c.Flags().String("branch-name", "", "Name of worktree branch (default: <from-branch>-worktree)")
+	c.Flags().String("editor", "", "Editor to open (default from config)")
+}
+
+func runWorktreeNew(cmd *cobra.Command, args []string) error {
+	cfg := config.Get()
+
+	usingTmux, _ := cmd.Flags().GetBool("using-tmux")
+	if !cmd.Flags().Changed("using-tmux") {
+		usingTmux = cfg.Branch.UsingTmux
+	}
+
+	fromBranch, _ := cmd.Flags().GetString("from-branch")
+	if fromBranch == "" {
+		currentBranch, err := App.Git.CurrentBranch()
+		if err != nil {
+			return fmt.Errorf("failed to get current branch: %v", err)
+		}
+		fromBranch = currentBranch
+	}
+
+	branchName, _ := cmd.Flags().GetString("branch-name")
+	if branchName == "" {
+		branchName = fromBranch + "-worktree"
+	}
+
+	editor, _ := cmd.Flags().GetString("editor")
+	if editor == "" {
+		editor = cfg.Branch.Editor
+	}
+
+	runtime := BranchRuntime{
+		UsingTmux:  usingTmux,
+		FromBranch: fromBranch,
+		BranchName: branchName,
+		Editor:     editor,
+	}
+
+	if !App.Git.IsRepo() {
+		return errs.NewErrorWithHint("check repository", fmt.Errorf("not in a git repository"),
+			"Run `git init` to create one, or `cd` into an existing git repository.")
+	}
+
+	worktreePath, err := createWorktree(runtime)
+	if err != nil {
+		return fmt.Errorf("error creating worktree: %v", err)
+	}
+
+	if err := openEditor(worktreePath, runtime); err != nil {
+		return fmt.Errorf("error opening editor: %v", err)
+	}
+
+	return nil
+}
+
+func createWorktree(runtime BranchRuntime) (string, error) {
+	repoRoot, err := App.Git.RepoRoot()
+	if err != nil {
+		return "", err
+	}
+
+	parentDir := filepath.Dir(repoRoot)
+	repoName := filepath.Base(repoRoot)
+	worktreePath := filepath.Join(parentDir, fmt.Sprintf("%s-%s", repoName, runtime.BranchName))
+
+	if err := App.Git.AddWorktree(worktreePath, runtime.BranchName, runtime.FromBranch); err != nil {
+		return "", err
+	}
+
+	state, err := wtstate.Load()
+	if err == nil {
+		_ = state.Add(worktreePath, runtime.BranchName)
+	}
+
+	fmt.Printf("Created worktree at: %s\n", worktreePath)
+	return worktreePath, nil
+}
+
+func openEditor(worktreePath string, runtime BranchRuntime) error {
+	if runtime.UsingTmux && isTmuxRunning() {
+		return openEditorInTmux(worktreePath, runtime)
+	}
+	return openEditorDirect(worktreePath, runtime)
+}
+
+func openEditorInTmux(worktreePath string, runtime BranchRuntime) error {
+	cmd := exec.Command("tmux", "new-window", "-c", worktreePath, "-n", filepath.Base(worktreePath))
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to create tmux window: %v", err)
+	}
+
+	cmd = exec.Command("tmux", "send-keys", "-t", fmt.Sprintf(":%s", filepath.Base(worktreePath)), runtime.Editor, "Enter")
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to open editor in tmux: %v", err)
+	}
+
+	fmt.Printf("Opened %s in tmux window at: %s\n", runtime.Editor, worktreePath)
+	return nil
+}
+
+func openEditorDirect(worktreePath string, runtime BranchRuntime) error {
+	cmd := exec.Command(runtime.Editor, worktreePath)
+	cmd.Dir = worktreePath
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to open editor: %v", err)
+	}
+
+	fmt.Printf("Opened %s at: %s\n", runtime.Editor, worktreePath)
+	return nil
+}
+
+func isTmuxRunning() bool {
+	return os.Getenv("TMUX") != ""
+}
+
+func runWorktreeLs(cmd *cobra.Command, args []string) error {
+	worktrees, err := App.Git.ListWorktrees()
+	if err != nil {
+		return fmt.Errorf("failed to list worktrees: %v", err)
+	}
+
+	defaultBranch, err := App.Git.DefaultBranch()
+	if err != nil {
+		defaultBranch = "main"
+	}
+
+	for _, wt := range worktrees {
+		merged, err := App.Git.IsBranchMerged(wt.Branch, defaultBranch)
+		if err != nil {
+			merged = false
+		}
+
+		hasRemote, err := App.Git.RemoteBranchExists(wt.Branch)
+		if err != nil {
+			hasRemote = false
+		}
+
+		fmt.Printf("%s\tbranch=%s\thead=%s\tmerged=%t\tremote=%t\n", wt.Path, wt.Branch, wt.Head, merged, hasRemote)
+	}
+
+	return nil
+}
+
+func resolveWorktreeArg(nameOrPath string) (*git.Worktree, error) {
+	worktrees, err := App.Git.ListWorktrees()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worktrees: %v", err)
+	}
+
+	for i := range worktrees {
+		wt := worktrees[i]
+		if wt.Path == nameOrPath || filepath.Base(wt.Path) == nameOrPath || wt.Branch == nameOrPath {
+			return &wt, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no worktree matching %q", nameOrPath)
+}
+
+func runWorktreeRm(cmd *cobra.Command, args []string) error {
+	wt, err := resolveWorktreeArg(args[0])
+	if err != nil {
+		return err
+	}
+
+	force, _ := cmd.Flags().GetBool("force")
+	deleteBranch, _ := cmd.Flags().GetBool("delete-branch")
+
+	if err := App.Git.RemoveWorktree(wt.Path, force); err != nil {
+		return fmt.Errorf("failed to remove worktree: %v", err)
+	}
+
+	if state, err := wtstate.Load(); err == nil {
+		_ = state.Remove(wt.Path)
+	}
+
+	if deleteBranch && wt.Branch != "" {
+		if output, err := gitcmd.New(context.Background(), "branch").
+			AddArguments("-D").
+			AddDynamicArguments(wt.Branch).
+			CombinedOutput(); err != nil {
+			return fmt.Errorf("removed worktree but failed to delete branch %s: %s", wt.Branch, output)
+		}
+	}
+
+	fmt.Printf("Removed worktree at: %s\n", wt.Path)
+	return nil
+}
+
+func runWorktreePrune(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	worktrees, err := App.Git.ListWorktrees()
+	if err != nil {
+		return fmt.Errorf("failed to list worktrees: %v", err)
+	}
+
+	defaultBranch, err := App.Git.DefaultBranch()
+	if err != nil {
+		defaultBranch = "main"
+	}
+
+	state, err := wtstate.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load worktree state: %v", err)
+	}
+
+	for _, wt := range worktrees {
+		if !state.Contains(wt.Path) {
+			continue
+		}
+
+		merged, _ := App.Git.IsBranchMerged(wt.Branch, defaultBranch)
+		hasRemote, _ := App.Git.RemoteBranchExists(wt.Branch)
+		hadUpstream, _ := App.Git.HasUpstream(wt.Branch)
+
+		// Only prune for an absent remote when the branch once had an
+		// upstream and it's now gone - a branch that was never pushed isn't
+		// "abandoned", it's still in progress.
+		upstreamDeleted := hadUpstream && !hasRemote
+
+		if !merged && !upstreamDeleted {
+			continue
+		}
+
+		if dryRun {
+			fmt.Printf("Would remove worktree at: %s (branch=%s, merged=%t, remote=%t)\n", wt.Path, wt.Branch, merged, hasRemote)
+			continue
+		}
+
+		if err := App.Git.RemoveWorktree(wt.Path, false); err != nil {
+			fmt.Printf("Failed to remove worktree at %s: %v\n", wt.Path, err)
+			continue
+		}
+		_ = state.Remove(wt.Path)
+
+		fmt.Printf("Removed worktree at: %s\n", wt.Path)
+	}
+
+	return nil
+}
+
+func runWorktreeCd(cmd *cobra.Command, args []string) error {
+	wt, err := resolveWorktreeArg(args[0])
+	if err != nil {
+		return err
+	}
+
+	useTmux, _ := cmd.Flags().GetBool("tmux")
+	if !useTmux {
+		fmt.Println(wt.Path)
+		return nil
+	}
+
+	windowName := filepath.Base(wt.Path)
+	if err := switchToTmuxWindow(windowName, wt.Path); err != nil {
+		return fmt.Errorf("failed to switch to tmux window: %v", err)
+	}
+
+	return nil
+}
+
+func switchToTmuxWindow(windowName, path string) error {
+	if exec.Command("tmux", "select-window", "-t", windowName).Run() == nil {
+		return nil
+	}
+	return exec.Command("tmux", "new-window", "-c", path, "-n", windowName).Run()
+}
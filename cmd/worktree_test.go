@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/MaiMarincic/bruh/pkg/git"
+)
+
+func TestResolveWorktreeArg(t *testing.T) {
+	fake := &git.FakeClient{
+		ListWorktreesFn: func() ([]git.Worktree, error) {
+			return []git.Worktree{
+				{Path: "/repo", Branch: "main"},
+				{Path: "/repo-feature-worktree", Branch: "feature-worktree"},
+			}, nil
+		},
+	}
+	prevGit := App.Git
+	App.Git = fake
+	defer func() { App.Git = prevGit }()
+
+	cases := []struct {
+		arg      string
+		wantPath string
+	}{
+		{"/repo-feature-worktree", "/repo-feature-worktree"},
+		{"repo-feature-worktree", "/repo-feature-worktree"},
+		{"feature-worktree", "/repo-feature-worktree"},
+	}
+
+	for _, tc := range cases {
+		wt, err := resolveWorktreeArg(tc.arg)
+		if err != nil {
+			t.Fatalf("resolveWorktreeArg(%q) returned error: %v", tc.arg, err)
+		}
+		if wt.Path != tc.wantPath {
+			t.Errorf("resolveWorktreeArg(%q).Path = %q, want %q", tc.arg, wt.Path, tc.wantPath)
+		}
+	}
+
+	if _, err := resolveWorktreeArg("nonexistent"); err == nil {
+		t.Error("expected error for unmatched worktree argument")
+	}
+}
@@ -3,6 +3,8 @@ package config
 type Config struct {
 	Branch           BranchConfig `mapstructure:"branch"`
 	PR               PRConfig     `mapstructure:"pr"`
+	AI               AIConfig     `mapstructure:"ai"`
+	Scan             ScanConfig   `mapstructure:"scan"`
 	CleanupPreCommit []string     `mapstructure:"cleanup-pre-commit"`
 }
 
@@ -13,6 +15,42 @@ type BranchConfig struct {
 
 type PRConfig struct {
 	Prompts map[string]string `mapstructure:"prompts"`
+	// IssueRepo is the "owner/repo" that `bruh scan --create-issues`
+	// files tracking issues against. Empty means the repository `gh` is
+	// currently operating in (the usual case); set it to track findings
+	// in a separate triage repo instead.
+	IssueRepo string `mapstructure:"issue_repo"`
+	// GithubToken, if set, is exported as GH_TOKEN for every `gh`
+	// invocation bruh makes, overriding the ambient `gh auth login`
+	// session - e.g. to file issues as a dedicated bot account in CI.
+	GithubToken string `mapstructure:"github_token"`
+}
+
+// AIConfig selects and configures the ai.Provider used to generate commit
+// messages, PR descriptions, and pre-commit fixes.
+type AIConfig struct {
+	// Provider is one of "claude-cli" (default), "openai", or
+	// "openai-compatible".
+	Provider string `mapstructure:"provider"`
+	// Model overrides the provider's default model, where applicable.
+	Model string `mapstructure:"model"`
+	// BaseURL is required for the "openai-compatible" provider, e.g.
+	// "http://localhost:11434/v1" for a local Ollama server.
+	BaseURL string `mapstructure:"base_url"`
+}
+
+// ScanConfig configures `bruh scan`'s vulnerability-database lookups and
+// the scan-target patterns it always excludes.
+type ScanConfig struct {
+	// OSVCacheTTL is how long a cached OSV.dev query result for a given
+	// (ecosystem, name, version) is trusted before it's re-fetched, as a
+	// duration string (e.g. "24h").
+	OSVCacheTTL string `mapstructure:"osv_cache_ttl"`
+	// Exclude is a list of Go-style scan-target patterns (e.g.
+	// "./vendor/...", "./testdata") always subtracted from what `bruh
+	// scan` covers, so ignore rules can be committed to the repo instead
+	// of retyped as "!" patterns on every invocation.
+	Exclude []string `mapstructure:"exclude"`
 }
 
 func DefaultConfig() *Config {
@@ -26,6 +64,12 @@ func DefaultConfig() *Config {
 				"default": "Based on the following git changes, create a PR. Execute the gh pr create command directly.",
 			},
 		},
+		AI: AIConfig{
+			Provider: "claude-cli",
+		},
+		Scan: ScanConfig{
+			OSVCacheTTL: "24h",
+		},
 		CleanupPreCommit: []string{},
 	}
 }
\ No newline at end of file
@@ -32,6 +32,10 @@ func Load() (*Config, error) {
 	v.SetDefault("branch.using_tmux", defaults.Branch.UsingTmux)
 	v.SetDefault("branch.editor", defaults.Branch.Editor)
 	v.SetDefault("pr.prompts", defaults.PR.Prompts)
+	v.SetDefault("ai.provider", defaults.AI.Provider)
+	v.SetDefault("ai.model", defaults.AI.Model)
+	v.SetDefault("ai.base_url", defaults.AI.BaseURL)
+	v.SetDefault("scan.osv_cache_ttl", defaults.Scan.OSVCacheTTL)
 
 	if err := v.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
@@ -59,6 +63,12 @@ func Get() *Config {
 	return globalConfig
 }
 
+// ConfigDir returns the directory bruh stores its config and state files in,
+// honoring XDG_CONFIG_HOME when set.
+func ConfigDir() string {
+	return getConfigDir()
+}
+
 func getConfigDir() string {
 	if xdgConfig := os.Getenv("XDG_CONFIG_HOME"); xdgConfig != "" {
 		return filepath.Join(xdgConfig, "bruh")
@@ -70,4 +80,37 @@ func getConfigDir() string {
 	}
 
 	return filepath.Join(home, ".config", "bruh")
+}
+
+// StateDir returns the directory bruh stores transient, rebuildable state
+// in (e.g. last-used choices), honoring XDG_STATE_HOME when set. Unlike
+// ConfigDir, StateDir's contents are never meant to be hand-edited.
+func StateDir() string {
+	if xdgState := os.Getenv("XDG_STATE_HOME"); xdgState != "" {
+		return filepath.Join(xdgState, "bruh")
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "."
+	}
+
+	return filepath.Join(home, ".local", "state", "bruh")
+}
+
+// CacheDir returns the directory bruh stores reusable, network-fetched
+// cache data in (e.g. OSV.dev query results), honoring XDG_CACHE_HOME when
+// set. Unlike StateDir, CacheDir's contents are safe to delete at any time
+// and will simply be re-fetched.
+func CacheDir() string {
+	if xdgCache := os.Getenv("XDG_CACHE_HOME"); xdgCache != "" {
+		return filepath.Join(xdgCache, "bruh")
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "."
+	}
+
+	return filepath.Join(home, ".cache", "bruh")
 }
\ No newline at end of file
@@ -0,0 +1,74 @@
+// Package addcheatstate remembers the last cheat sheet `bruh addcheat -i`
+// targeted for each cheat directory, so repeated invocations can default to
+// the previous choice instead of asking from scratch every time.
+package addcheatstate
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/MaiMarincic/bruh/config"
+)
+
+// Store is the on-disk map of cheat directory to last-chosen sheet,
+// persisted under $XDG_STATE_HOME/bruh/addcheat.json.
+type Store struct {
+	path string
+
+	// LastSheet maps an absolute cheat directory to the path of the sheet
+	// last chosen in it.
+	LastSheet map[string]string `json:"last_sheet"`
+}
+
+// Load reads the state file, returning an empty Store if it doesn't exist
+// yet.
+func Load() (*Store, error) {
+	path := filepath.Join(config.StateDir(), "addcheat.json")
+
+	s := &Store{path: path, LastSheet: map[string]string{}}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	s.path = path
+	if s.LastSheet == nil {
+		s.LastSheet = map[string]string{}
+	}
+
+	return s, nil
+}
+
+// Last returns the sheet path last chosen for cheatDir, or "" if none is
+// recorded.
+func (s *Store) Last(cheatDir string) string {
+	return s.LastSheet[cheatDir]
+}
+
+// SetLast records sheetPath as the last choice for cheatDir and persists
+// the change.
+func (s *Store) SetLast(cheatDir, sheetPath string) error {
+	s.LastSheet[cheatDir] = sheetPath
+	return s.save()
+}
+
+func (s *Store) save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, data, 0o644)
+}
@@ -0,0 +1,94 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/MaiMarincic/bruh/internal/errs"
+)
+
+// installHint is shown whenever the claude binary can't be found or fails,
+// since that's almost always a PATH or install problem rather than a prompt
+// problem.
+const installHint = "Install Claude Code from https://docs.claude.com/claude-code and ensure `claude` is on your PATH."
+
+// ClaudeCLI shells out to the `claude` binary. It's the default provider and
+// preserves bruh's original behavior from before providers existed.
+type ClaudeCLI struct {
+	// Verbose reports whether error hints should include the raw stderr of
+	// failed invocations. It's a func rather than a bool so it can read the
+	// global --verbose flag at call time instead of at provider construction.
+	Verbose func() bool
+}
+
+// NewClaudeCLI returns a ClaudeCLI provider. verbose is consulted on every
+// call, so it can be backed by a flag that's parsed after the provider is
+// constructed.
+func NewClaudeCLI(verbose func() bool) *ClaudeCLI {
+	return &ClaudeCLI{Verbose: verbose}
+}
+
+func (c *ClaudeCLI) Name() string { return "claude-cli" }
+
+// SupportsToolExecution reports that Generate, given AllowedTools, actually
+// runs the requested command rather than just describing it.
+func (c *ClaudeCLI) SupportsToolExecution() bool { return true }
+
+func (c *ClaudeCLI) Generate(ctx context.Context, prompt string, opts GenerateOpts) (string, error) {
+	args := []string{"--print"}
+	if opts.AllowedTools != "" {
+		args = append(args, "--allowedTools", opts.AllowedTools)
+	}
+	args = append(args, "--", prompt)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "claude", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		hint := errs.WithStderr(installHint, stderr.String(), c.Verbose())
+		return "", errs.NewErrorWithHint("generate text with Claude", err, hint)
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+func (c *ClaudeCLI) Agent(ctx context.Context, prompt string, tools []Tool) (string, error) {
+	names := make([]string, len(tools))
+	for i, t := range tools {
+		names[i] = t.Name
+	}
+
+	args := []string{"--print", "--dangerously-skip-permissions"}
+	if len(names) > 0 {
+		args = append(args, "--allowedTools", strings.Join(names, ","))
+	}
+	args = append(args, "--", prompt)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "claude", args...)
+	cmd.Stdin = strings.NewReader("")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		hint := errs.WithStderr(installHint, stderr.String(), c.Verbose())
+		return "", errs.NewErrorWithHint("run Claude agent", err, hint)
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// Doctor verifies the claude binary is installed and runnable.
+func (c *ClaudeCLI) Doctor(ctx context.Context) error {
+	cmd := exec.CommandContext(ctx, "claude", "--version")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		hint := errs.WithStderr(installHint, stderr.String(), c.Verbose())
+		return errs.NewErrorWithHint("check claude CLI", fmt.Errorf("`claude --version` failed"), hint)
+	}
+	return nil
+}
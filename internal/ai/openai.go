@@ -0,0 +1,129 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/MaiMarincic/bruh/internal/errs"
+)
+
+// OpenAICompatible talks to any chat-completions-compatible HTTP API given a
+// base URL and API key, which covers Ollama, Groq, OpenRouter, and other
+// self-hosted or third-party endpoints in addition to OpenAI itself.
+type OpenAICompatible struct {
+	BaseURL string
+	APIKey  string
+	Model   string
+
+	HTTPClient *http.Client
+}
+
+// NewOpenAICompatible returns a provider that posts chat completion requests
+// to baseURL (no trailing slash, e.g. "https://api.openai.com/v1").
+func NewOpenAICompatible(baseURL, apiKey, model string) *OpenAICompatible {
+	return &OpenAICompatible{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		APIKey:     apiKey,
+		Model:      model,
+		HTTPClient: &http.Client{Timeout: 2 * time.Minute},
+	}
+}
+
+// NewOpenAI returns an OpenAICompatible provider pre-configured for
+// api.openai.com.
+func NewOpenAI(apiKey, model string) *OpenAICompatible {
+	return NewOpenAICompatible("https://api.openai.com/v1", apiKey, model)
+}
+
+func (o *OpenAICompatible) Name() string { return "openai-compatible:" + o.BaseURL }
+
+// SupportsToolExecution reports that chat completions APIs have no tool
+// access: Generate only ever returns text, regardless of AllowedTools.
+func (o *OpenAICompatible) SupportsToolExecution() bool { return false }
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatCompletionRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+}
+
+type chatCompletionResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (o *OpenAICompatible) Generate(ctx context.Context, prompt string, opts GenerateOpts) (string, error) {
+	model := o.Model
+	if opts.Model != "" {
+		model = opts.Model
+	}
+
+	reqBody, err := json.Marshal(chatCompletionRequest{
+		Model:    model,
+		Messages: []chatMessage{{Role: "user", Content: prompt}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.BaseURL+"/chat/completions", bytes.NewReader(reqBody))
+	if err != nil {
+		return "", fmt.Errorf("failed to build request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+o.APIKey)
+
+	resp, err := o.HTTPClient.Do(req)
+	if err != nil {
+		return "", errs.NewErrorWithHint("generate text with "+o.Name(), err,
+			fmt.Sprintf("Check that %s is reachable and that the configured API key is valid.", o.BaseURL))
+	}
+	defer resp.Body.Close()
+
+	var parsed chatCompletionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return "", fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	if parsed.Error != nil {
+		return "", errs.NewErrorWithHint("generate text with "+o.Name(), fmt.Errorf("%s", parsed.Error.Message),
+			"Check the configured API key and model name.")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errs.NewErrorWithHint("generate text with "+o.Name(), fmt.Errorf("unexpected status %s", resp.Status),
+			"Check the configured API key, model name, and base URL.")
+	}
+
+	if len(parsed.Choices) == 0 {
+		return "", fmt.Errorf("no choices returned from %s", o.Name())
+	}
+
+	return strings.TrimSpace(parsed.Choices[0].Message.Content), nil
+}
+
+// Agent is unsupported: chat completions APIs have no file-editing tool
+// access the way the Claude Code CLI does.
+func (o *OpenAICompatible) Agent(ctx context.Context, prompt string, tools []Tool) (string, error) {
+	return "", fmt.Errorf("%s does not support agentic tool use; use the claude-cli provider for pre-commit cleanup", o.Name())
+}
+
+// Doctor verifies the endpoint is reachable and the API key is accepted by
+// sending a minimal completion request.
+func (o *OpenAICompatible) Doctor(ctx context.Context) error {
+	_, err := o.Generate(ctx, "Reply with the single word: ok", GenerateOpts{})
+	return err
+}
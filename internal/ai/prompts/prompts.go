@@ -0,0 +1,113 @@
+// Package prompts holds the named prompt templates bruh sends to its AI
+// provider. Each template can be overridden per repo by placing a file at
+// .bruh/prompts/<name>.tmpl in the repository root.
+package prompts
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+var defaults = map[string]string{
+	"commit":         commitTemplate,
+	"pr":             prTemplate,
+	"fix-pre-commit": fixPreCommitTemplate,
+}
+
+// Render executes the named template against data, preferring a repo-local
+// override at .bruh/prompts/<name>.tmpl over the built-in default.
+func Render(repoRoot, name string, data any) (string, error) {
+	text, err := load(repoRoot, name)
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse prompt template %q: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render prompt template %q: %v", name, err)
+	}
+
+	return buf.String(), nil
+}
+
+func load(repoRoot, name string) (string, error) {
+	if repoRoot != "" {
+		override := filepath.Join(repoRoot, ".bruh", "prompts", name+".tmpl")
+		if data, err := os.ReadFile(override); err == nil {
+			return string(data), nil
+		}
+	}
+
+	text, ok := defaults[name]
+	if !ok {
+		return "", fmt.Errorf("unknown prompt template %q", name)
+	}
+	return text, nil
+}
+
+// CommitData is the template data for the "commit" prompt.
+type CommitData struct {
+	Status string
+	Diff   string
+}
+
+const commitTemplate = `Based on the following git changes, generate a concise, short, well-formed commit message following conventional commit format:
+
+Git Status:
+{{.Status}}
+
+Changed Files:
+{{.Diff}}
+
+Do not mention anything in the likes of written by AI.
+Please provide only the commit message without any additional text or explanation.`
+
+// PRData is the template data for the "pr" prompt.
+type PRData struct {
+	CurrentBranch string
+	BaseBranch    string
+	Diff          string
+	Log           string
+	DetailedDiff  string
+}
+
+const prTemplate = `Based on the following git changes between {{.CurrentBranch}} and {{.BaseBranch}} branches, create a pull request using the gh CLI.
+
+Changed Files:
+{{.Diff}}
+
+Commit History:
+{{.Log}}
+
+Detailed Changes:
+{{.DetailedDiff}}
+
+Please use the gh pr create command with the following requirements:
+1. Generate a concise, descriptive PR title
+2. Create a comprehensive PR body that includes:
+   - A summary section with 2-3 bullet points explaining what this PR does
+   - A test plan section with specific testing criteria and checklist items
+3. Use the --allowedTools flag to enable the gh tool
+4. The PR body should be well-formatted with markdown
+5. Include "🤖 Generated with Claude Code" at the end of the body
+
+Important: Execute the gh pr create command directly. Do not just return the command or description text.`
+
+// FixPreCommitData is the template data for the "fix-pre-commit" prompt.
+type FixPreCommitData struct {
+	Output string
+}
+
+const fixPreCommitTemplate = `Fix the following pre-commit issues in the current directory:
+
+{{.Output}}
+
+Please analyze the errors and fix all the issues automatically. Only fix the issues, don't explain what you're doing.`
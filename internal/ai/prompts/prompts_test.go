@@ -0,0 +1,45 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRender_UsesDefaultWhenNoOverride(t *testing.T) {
+	out, err := Render(t.TempDir(), "commit", CommitData{Status: "M foo.go", Diff: "foo.go"})
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	if !strings.Contains(out, "M foo.go") || !strings.Contains(out, "foo.go") {
+		t.Errorf("expected rendered prompt to include template data, got %q", out)
+	}
+}
+
+func TestRender_PrefersRepoOverride(t *testing.T) {
+	repoRoot := t.TempDir()
+	promptsDir := filepath.Join(repoRoot, ".bruh", "prompts")
+	if err := os.MkdirAll(promptsDir, 0o755); err != nil {
+		t.Fatalf("failed to create prompts dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(promptsDir, "commit.tmpl"), []byte("custom: {{.Status}}"), 0o644); err != nil {
+		t.Fatalf("failed to write override: %v", err)
+	}
+
+	out, err := Render(repoRoot, "commit", CommitData{Status: "M foo.go"})
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	if got, want := out, "custom: M foo.go"; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestRender_UnknownTemplate(t *testing.T) {
+	if _, err := Render("", "does-not-exist", nil); err == nil {
+		t.Fatal("expected an error for an unknown template name")
+	}
+}
@@ -0,0 +1,55 @@
+// Package ai defines a provider-agnostic interface for generating text with
+// an LLM, so that commands like `commit` and `pr` aren't hard-wired to the
+// Claude Code CLI.
+package ai
+
+import "context"
+
+// GenerateOpts configures a single Generate call.
+type GenerateOpts struct {
+	// Model overrides the provider's configured default model, if set.
+	// The ClaudeCLI provider ignores it.
+	Model string
+	// AllowedTools restricts what the underlying agent may touch while
+	// generating, in the provider's own tool-permission syntax (e.g. the
+	// ClaudeCLI provider expects values like "Bash(git:*)"). Providers that
+	// have no concept of tool permissions ignore this.
+	AllowedTools string
+}
+
+// Tool describes a capability granted to Agent for providers that support
+// agentic tool use, such as letting Claude Code edit files to fix pre-commit
+// failures. The name is provider-specific syntax (see GenerateOpts.AllowedTools).
+type Tool struct {
+	Name string
+}
+
+// DoctorChecker is implemented by providers that can verify their own
+// reachability, for `bruh ai doctor`.
+type DoctorChecker interface {
+	Doctor(ctx context.Context) error
+}
+
+// ToolCapable is implemented by providers whose Generate call can actually
+// invoke external tools via GenerateOpts.AllowedTools (e.g. running `gh pr
+// create` itself), as opposed to providers that only ever return text.
+// Callers that depend on the tool actually running, rather than just being
+// described, should check this before relying on AllowedTools.
+type ToolCapable interface {
+	SupportsToolExecution() bool
+}
+
+// Provider is an LLM backend capable of one-shot text generation and, for
+// providers that support it, agentic tool use.
+type Provider interface {
+	// Name identifies the provider in user-facing output, e.g. "claude-cli".
+	Name() string
+
+	// Generate returns the model's response to prompt.
+	Generate(ctx context.Context, prompt string, opts GenerateOpts) (string, error)
+
+	// Agent runs prompt with access to the given tools, allowing the model to
+	// take actions (edit files, run commands) rather than just respond with
+	// text. Providers that don't support tool use return an error.
+	Agent(ctx context.Context, prompt string, tools []Tool) (string, error)
+}
@@ -0,0 +1,42 @@
+// Package errs wraps errors with an actionable hint, so failures like a
+// missing CLI dependency or an unauthenticated tool can tell the user what
+// to do next instead of surfacing a bare one-line message.
+package errs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrorWithHint is an error annotated with a human-readable suggestion for
+// how to resolve it.
+type ErrorWithHint struct {
+	Task string
+	Err  error
+	Hint string
+}
+
+func (e *ErrorWithHint) Error() string {
+	return fmt.Sprintf("%s: %v", e.Task, e.Err)
+}
+
+func (e *ErrorWithHint) Unwrap() error {
+	return e.Err
+}
+
+// NewErrorWithHint wraps err with a task description and a hint explaining
+// how the user can resolve the failure.
+func NewErrorWithHint(task string, err error, hint string) error {
+	return &ErrorWithHint{Task: task, Err: err, Hint: hint}
+}
+
+// WithStderr appends the raw stderr of a failed command to hint, but only
+// when verbose is true and stderr isn't empty. This lets callers build a
+// hint once and let --verbose decide whether to attach the raw output.
+func WithStderr(hint, stderr string, verbose bool) string {
+	stderr = strings.TrimSpace(stderr)
+	if !verbose || stderr == "" {
+		return hint
+	}
+	return fmt.Sprintf("%s\n\nCommand stderr:\n%s", hint, stderr)
+}
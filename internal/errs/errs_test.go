@@ -0,0 +1,37 @@
+package errs
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestErrorWithHint_Unwrap(t *testing.T) {
+	base := errors.New("boom")
+	err := NewErrorWithHint("do thing", base, "try again")
+
+	if !errors.Is(err, base) {
+		t.Fatal("expected errors.Is to find the wrapped error")
+	}
+
+	if got, want := err.Error(), "do thing: boom"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestWithStderr(t *testing.T) {
+	hint := WithStderr("install the tool", "", true)
+	if hint != "install the tool" {
+		t.Errorf("expected empty stderr to leave hint unchanged, got %q", hint)
+	}
+
+	hint = WithStderr("install the tool", "exit status 1", false)
+	if hint != "install the tool" {
+		t.Errorf("expected verbose=false to leave hint unchanged, got %q", hint)
+	}
+
+	hint = WithStderr("install the tool", "exit status 1", true)
+	if !strings.Contains(hint, "exit status 1") {
+		t.Errorf("expected verbose hint to include stderr, got %q", hint)
+	}
+}
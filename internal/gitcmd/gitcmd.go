@@ -0,0 +1,139 @@
+// Package gitcmd builds git invocations in a way that keeps trusted,
+// hardcoded arguments separate from dynamic values (branch names, paths,
+// refs) that originate from user input or repository state. Dynamic values
+// are validated before they reach exec.Command so they cannot be mistaken
+// for a flag by git (e.g. a branch named "--upload-pack=..." or "-b;rm -rf").
+package gitcmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// TrustedArg marks an argument as a hardcoded literal that the caller wrote
+// in source code, as opposed to a value derived from user input.
+type TrustedArg string
+
+// Command builds a single "git <subcmd> ..." invocation.
+type Command struct {
+	ctx  context.Context
+	name string
+	args []string
+	err  error
+}
+
+// New starts building a git invocation for the given subcommand.
+func New(ctx context.Context, subcmd TrustedArg) *Command {
+	return &Command{
+		ctx:  ctx,
+		name: "git",
+		args: []string{string(subcmd)},
+	}
+}
+
+// AddArguments appends trusted, hardcoded arguments such as flags or
+// subcommand names.
+func (c *Command) AddArguments(args ...TrustedArg) *Command {
+	for _, a := range args {
+		c.args = append(c.args, string(a))
+	}
+	return c
+}
+
+// AddOptionValues appends a trusted option followed by one or more dynamic
+// values, e.g. AddOptionValues("-b", branchName).
+func (c *Command) AddOptionValues(opt string, values ...string) *Command {
+	c.args = append(c.args, opt)
+	return c.AddDynamicArguments(values...)
+}
+
+// AddOptionFormat appends a single trusted argument built with fmt.Sprintf,
+// e.g. AddOptionFormat("%s...HEAD", baseBranch). The format string must be a
+// trusted literal written in source code.
+func (c *Command) AddOptionFormat(format string, a ...any) *Command {
+	c.args = append(c.args, fmt.Sprintf(format, a...))
+	return c
+}
+
+// AddDynamicArguments appends values that originate from user input or
+// repository state. Each value is validated and, if rejected, the Command
+// remembers the error so the eventual Run call fails instead of the
+// malformed value reaching git.
+func (c *Command) AddDynamicArguments(values ...string) *Command {
+	for _, v := range values {
+		if err := validateDynamicArg(v); err != nil {
+			if c.err == nil {
+				c.err = err
+			}
+			continue
+		}
+		c.args = append(c.args, v)
+	}
+	return c
+}
+
+func validateDynamicArg(v string) error {
+	if v == "" {
+		return fmt.Errorf("gitcmd: empty dynamic argument is not allowed")
+	}
+	if strings.HasPrefix(v, "-") {
+		return fmt.Errorf("gitcmd: dynamic argument %q must not start with '-'", v)
+	}
+	if strings.ContainsAny(v, "\x00\n") {
+		return fmt.Errorf("gitcmd: dynamic argument %q contains NUL or newline", v)
+	}
+	return nil
+}
+
+// RunOpts configures how a Command is executed.
+type RunOpts struct {
+	Dir   string
+	Env   []string
+	Stdin io.Reader
+}
+
+// RunStdString runs the command and returns its stdout and stderr as
+// trimmed-free strings, along with any error.
+func (c *Command) RunStdString(opts *RunOpts) (string, string, error) {
+	if c.err != nil {
+		return "", "", c.err
+	}
+
+	cmd := c.buildExecCmd(opts)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	return stdout.String(), stderr.String(), err
+}
+
+// CombinedOutput runs the command and returns its combined stdout/stderr.
+func (c *Command) CombinedOutput() ([]byte, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.buildExecCmd(nil).CombinedOutput()
+}
+
+func (c *Command) buildExecCmd(opts *RunOpts) *exec.Cmd {
+	var cmd *exec.Cmd
+	if c.ctx != nil {
+		cmd = exec.CommandContext(c.ctx, c.name, c.args...)
+	} else {
+		cmd = exec.Command(c.name, c.args...)
+	}
+
+	if opts != nil {
+		cmd.Dir = opts.Dir
+		cmd.Env = opts.Env
+		cmd.Stdin = opts.Stdin
+	}
+
+	return cmd
+}
@@ -0,0 +1,52 @@
+package gitcmd
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAddDynamicArguments_RejectsBadShapes(t *testing.T) {
+	cases := []struct {
+		name string
+		arg  string
+	}{
+		{"empty", ""},
+		{"flag-like", "--upload-pack=evil"},
+		{"short-flag", "-b"},
+		{"newline", "main\nrm -rf /"},
+		{"nul", "main\x00"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := New(context.Background(), "diff").AddDynamicArguments(tc.arg)
+			if _, _, err := c.RunStdString(nil); err == nil {
+				t.Fatalf("expected RunStdString to reject dynamic argument %q, got nil error", tc.arg)
+			}
+			if _, err := c.CombinedOutput(); err == nil {
+				t.Fatalf("expected CombinedOutput to reject dynamic argument %q, got nil error", tc.arg)
+			}
+		})
+	}
+}
+
+func TestAddDynamicArguments_AcceptsValidShapes(t *testing.T) {
+	cases := []string{"main", "feature/foo", "origin/main", "v1.2.3"}
+
+	for _, arg := range cases {
+		c := New(context.Background(), "rev-parse").AddDynamicArguments(arg)
+		if c.err != nil {
+			t.Fatalf("unexpected rejection of valid argument %q: %v", arg, c.err)
+		}
+	}
+}
+
+func TestAddOptionValues_ValidatesValues(t *testing.T) {
+	c := New(context.Background(), "worktree").
+		AddArguments("add").
+		AddOptionValues("-b", "--sneaky")
+
+	if c.err == nil {
+		t.Fatal("expected AddOptionValues to validate its dynamic values")
+	}
+}
@@ -0,0 +1,31 @@
+// Package govuln runs govulncheck in-process via golang.org/x/vuln/scan
+// instead of shelling out to a separately-installed govulncheck binary, so
+// its JSON output can be consumed as bytes without depending on PATH.
+package govuln
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"golang.org/x/vuln/scan"
+)
+
+// Run scans args (typically "./...") and returns govulncheck's raw
+// "-json" output.
+func Run(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := scan.Command(ctx, append([]string{"-json"}, args...)...)
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = io.Discard
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	if err := cmd.Wait(); err != nil {
+		return nil, err
+	}
+
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,78 @@
+package osv
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Cache stores OSV.dev query results on disk, keyed by (ecosystem, name,
+// version), so repeated scans of an unchanged lockfile don't re-hit the
+// network every time.
+type Cache struct {
+	dir string
+	ttl time.Duration
+}
+
+// NewCache returns a Cache rooted at dir (created lazily on first Put)
+// whose entries expire after ttl.
+func NewCache(dir string, ttl time.Duration) *Cache {
+	return &Cache{dir: dir, ttl: ttl}
+}
+
+type cacheEntry struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Vulns     []Vuln    `json:"vulns"`
+}
+
+// Get returns the cached vulns for p, and false if there's no entry or the
+// entry is older than the cache's TTL.
+func (c *Cache) Get(p Package) ([]Vuln, bool) {
+	data, err := os.ReadFile(c.path(p))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+
+	if time.Since(entry.FetchedAt) > c.ttl {
+		return nil, false
+	}
+
+	return entry.Vulns, true
+}
+
+// Put records vulns as the current result for p.
+func (c *Cache) Put(p Package, vulns []Vuln) error {
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(cacheEntry{FetchedAt: time.Now(), Vulns: vulns})
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.path(p), data, 0o644)
+}
+
+func (c *Cache) path(p Package) string {
+	key := sanitizeFilename(p.Ecosystem + "_" + p.Name + "_" + p.Version)
+	return filepath.Join(c.dir, key+".json")
+}
+
+func sanitizeFilename(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', ' ':
+			return '_'
+		default:
+			return r
+		}
+	}, s)
+}
@@ -0,0 +1,125 @@
+package osv
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// ParsePackageJSON extracts npm-ecosystem packages from a package.json's
+// dependencies and devDependencies.
+func ParsePackageJSON(data []byte) ([]Package, error) {
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, err
+	}
+
+	var pkgs []Package
+	for name, version := range pkg.Dependencies {
+		pkgs = append(pkgs, Package{Ecosystem: "npm", Name: name, Version: cleanSemver(version)})
+	}
+	for name, version := range pkg.DevDependencies {
+		pkgs = append(pkgs, Package{Ecosystem: "npm", Name: name, Version: cleanSemver(version)})
+	}
+	return pkgs, nil
+}
+
+func cleanSemver(v string) string {
+	return strings.TrimLeft(v, "^~=>v ")
+}
+
+// ParseRequirementsTxt extracts PyPI-ecosystem packages from a
+// requirements.txt, skipping comments, blank lines, and option flags
+// (-r, --index-url, ...). Lines without a pinned "==" version are
+// skipped, since there's no version to query OSV.dev with.
+func ParseRequirementsTxt(data []byte) ([]Package, error) {
+	var pkgs []Package
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+			continue
+		}
+
+		name, version, ok := strings.Cut(line, "==")
+		if !ok {
+			continue
+		}
+
+		name = strings.TrimSpace(strings.SplitN(name, "[", 2)[0])
+		version = strings.TrimSpace(strings.SplitN(version, ";", 2)[0])
+		if name == "" || version == "" {
+			continue
+		}
+
+		pkgs = append(pkgs, Package{Ecosystem: "PyPI", Name: name, Version: version})
+	}
+
+	return pkgs, scanner.Err()
+}
+
+// ParsePoetryLock extracts PyPI-ecosystem packages from a poetry.lock.
+func ParsePoetryLock(data []byte) ([]Package, error) {
+	return parseTOMLPackageTables(data, "PyPI"), nil
+}
+
+// ParseCargoLock extracts crates.io-ecosystem packages from a Cargo.lock.
+func ParseCargoLock(data []byte) ([]Package, error) {
+	return parseTOMLPackageTables(data, "crates.io"), nil
+}
+
+// parseTOMLPackageTables pulls name/version pairs out of the repeated
+// [[package]] tables both poetry.lock and Cargo.lock use, without pulling
+// in a full TOML parser as a dependency: every entry we care about is a
+// flat "key = "value"" line inside one of those tables.
+func parseTOMLPackageTables(data []byte, ecosystem string) []Package {
+	var pkgs []Package
+	var name, version string
+	inPackage := false
+
+	flush := func() {
+		if inPackage && name != "" && version != "" {
+			pkgs = append(pkgs, Package{Ecosystem: ecosystem, Name: name, Version: version})
+		}
+		name, version = "", ""
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "[[package]]" {
+			flush()
+			inPackage = true
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			flush()
+			inPackage = false
+			continue
+		}
+		if !inPackage {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+		switch strings.TrimSpace(key) {
+		case "name":
+			name = value
+		case "version":
+			version = value
+		}
+	}
+	flush()
+
+	return pkgs
+}
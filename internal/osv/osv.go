@@ -0,0 +1,191 @@
+// Package osv queries the OSV.dev vulnerability database
+// (https://ossf.github.io/osv-schema/) for dependencies bruh can't cover
+// with a native scanner (govulncheck already handles Go modules), parsing
+// package.json, requirements.txt, poetry.lock, and Cargo.lock into
+// (ecosystem, name, version) tuples and batching them against the API.
+package osv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// defaultBaseURL is OSV.dev's public API. It's overridden per-ecosystem by
+// baseURLFor, which lets Go-ecosystem queries honor GOVULNDB.
+const defaultBaseURL = "https://api.osv.dev"
+
+// Package identifies a single dependency the way OSV.dev expects it:
+// an ecosystem name ("npm", "PyPI", "crates.io", "Go", ...), a package
+// name, and a resolved version.
+type Package struct {
+	Ecosystem string
+	Name      string
+	Version   string
+}
+
+// Vuln is the subset of an OSV.dev vulnerability record bruh surfaces in a
+// report.Finding.
+type Vuln struct {
+	ID      string `json:"id"`
+	Summary string `json:"summary"`
+}
+
+// Client queries OSV.dev, consulting Cache before making any request and
+// populating it afterward.
+type Client struct {
+	HTTP  *http.Client
+	Cache *Cache
+}
+
+// NewClient returns a Client backed by cache. cache may be nil, in which
+// case every query goes straight to the network.
+func NewClient(cache *Cache) *Client {
+	return &Client{
+		HTTP:  &http.Client{Timeout: 15 * time.Second},
+		Cache: cache,
+	}
+}
+
+type queryBatchRequest struct {
+	Queries []query `json:"queries"`
+}
+
+type query struct {
+	Package packageRef `json:"package"`
+	Version string     `json:"version,omitempty"`
+}
+
+type packageRef struct {
+	Name      string `json:"name"`
+	Ecosystem string `json:"ecosystem"`
+}
+
+type queryBatchResponse struct {
+	Results []struct {
+		Vulns []struct {
+			ID string `json:"id"`
+		} `json:"vulns"`
+	} `json:"results"`
+}
+
+// Query resolves vulns for every package in pkgs, grouped by ecosystem so
+// that each ecosystem's batch request can be routed to its own base URL
+// (see baseURLFor). Results already present in the cache are never
+// re-fetched.
+func (c *Client) Query(ctx context.Context, pkgs []Package) (map[Package][]Vuln, error) {
+	results := make(map[Package][]Vuln, len(pkgs))
+
+	byEcosystem := map[string][]Package{}
+	for _, p := range pkgs {
+		if c.Cache != nil {
+			if vulns, ok := c.Cache.Get(p); ok {
+				results[p] = vulns
+				continue
+			}
+		}
+		byEcosystem[p.Ecosystem] = append(byEcosystem[p.Ecosystem], p)
+	}
+
+	for ecosystem, misses := range byEcosystem {
+		if err := c.queryBatch(ctx, ecosystem, misses, results); err != nil {
+			return results, err
+		}
+	}
+
+	return results, nil
+}
+
+func (c *Client) queryBatch(ctx context.Context, ecosystem string, pkgs []Package, results map[Package][]Vuln) error {
+	req := queryBatchRequest{}
+	for _, p := range pkgs {
+		req.Queries = append(req.Queries, query{
+			Package: packageRef{Name: p.Name, Ecosystem: p.Ecosystem},
+			Version: p.Version,
+		})
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURLFor(ecosystem)+"/v1/querybatch", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTP.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("osv querybatch for %s: %w", ecosystem, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("osv querybatch for %s: unexpected status %s", ecosystem, resp.Status)
+	}
+
+	var batchResp queryBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&batchResp); err != nil {
+		return err
+	}
+
+	for i, p := range pkgs {
+		if i >= len(batchResp.Results) {
+			continue
+		}
+
+		var vulns []Vuln
+		for _, v := range batchResp.Results[i].Vulns {
+			vulns = append(vulns, c.vulnDetail(ctx, v.ID))
+		}
+
+		results[p] = vulns
+		if c.Cache != nil {
+			_ = c.Cache.Put(p, vulns)
+		}
+	}
+
+	return nil
+}
+
+// vulnDetail fetches a vuln's summary. A failed lookup degrades to an
+// ID-only Vuln rather than dropping the finding entirely.
+func (c *Client) vulnDetail(ctx context.Context, id string) Vuln {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, defaultBaseURL+"/v1/vulns/"+id, nil)
+	if err != nil {
+		return Vuln{ID: id}
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return Vuln{ID: id}
+	}
+	defer resp.Body.Close()
+
+	var detail Vuln
+	if err := json.NewDecoder(resp.Body).Decode(&detail); err != nil {
+		return Vuln{ID: id}
+	}
+	return detail
+}
+
+// baseURLFor resolves the OSV.dev base URL to query for ecosystem. Only
+// the Go ecosystem is redirectable, via GOVULNDB - the same env var
+// golang.org/x/vuln itself honors to point at a vulnerability database
+// mirror - so air-gapped users can point Go queries at an internal mirror
+// without affecting npm/PyPI/crates.io queries.
+func baseURLFor(ecosystem string) string {
+	if ecosystem == "Go" {
+		if db := os.Getenv("GOVULNDB"); db != "" {
+			return strings.TrimSuffix(db, "/")
+		}
+	}
+	return defaultBaseURL
+}
@@ -0,0 +1,170 @@
+package scanjobs
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+type scannerState struct {
+	status    Status
+	startedAt time.Time
+}
+
+// progressView renders one line per in-flight scanner (name + elapsed
+// time + status), redrawing in place on a tick. When stdout isn't a
+// terminal (piped to a file, running in CI), it falls back to printing a
+// plain event line per state transition instead of control codes.
+type progressView struct {
+	mu       sync.Mutex
+	states   map[string]*scannerState
+	order    []string
+	lastDraw int
+
+	ticker  *time.Ticker
+	done    chan struct{}
+	enabled bool
+}
+
+func newProgressView() *progressView {
+	v := &progressView{
+		states:  map[string]*scannerState{},
+		done:    make(chan struct{}),
+		enabled: isTerminal(os.Stdout),
+	}
+	if v.enabled {
+		v.ticker = time.NewTicker(150 * time.Millisecond)
+		go v.loop()
+	}
+	return v
+}
+
+func (v *progressView) loop() {
+	for {
+		select {
+		case <-v.ticker.C:
+			v.draw()
+		case <-v.done:
+			return
+		}
+	}
+}
+
+func (v *progressView) start(name string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if _, ok := v.states[name]; !ok {
+		v.order = append(v.order, name)
+	}
+	v.states[name] = &scannerState{status: StatusPending, startedAt: time.Now()}
+
+	if !v.enabled {
+		fmt.Printf("• %s: queued\n", name)
+	}
+}
+
+func (v *progressView) running(name string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if s, ok := v.states[name]; ok {
+		s.status = StatusRunning
+		s.startedAt = time.Now()
+	}
+
+	if !v.enabled {
+		fmt.Printf("▶ %s: running\n", name)
+	}
+}
+
+func (v *progressView) skip(name string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.states[name] = &scannerState{status: StatusSkipped}
+
+	if !v.enabled {
+		fmt.Printf("- %s: skipped\n", name)
+	}
+}
+
+// finish marks name as no longer in-flight. The scanner's own completion
+// message is printed separately by the Pool's onDone callback; finish
+// only stops that scanner's line from showing up in the next redraw.
+func (v *progressView) finish(name string, status Status) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if s, ok := v.states[name]; ok {
+		s.status = status
+	}
+}
+
+func (v *progressView) stop() {
+	if !v.enabled {
+		return
+	}
+	v.ticker.Stop()
+	close(v.done)
+	v.clear()
+}
+
+func (v *progressView) draw() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.clearLocked()
+
+	active := make([]string, 0, len(v.order))
+	for _, name := range v.order {
+		if s := v.states[name]; s != nil && (s.status == StatusPending || s.status == StatusRunning) {
+			active = append(active, name)
+		}
+	}
+	sort.Strings(active)
+
+	for _, name := range active {
+		s := v.states[name]
+		fmt.Printf("%s %s %s\n", statusGlyph(s.status), name, time.Since(s.startedAt).Round(time.Second))
+	}
+	v.lastDraw = len(active)
+}
+
+func (v *progressView) clear() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.clearLocked()
+}
+
+func (v *progressView) clearLocked() {
+	for i := 0; i < v.lastDraw; i++ {
+		fmt.Print("\x1b[1A\x1b[2K")
+	}
+	v.lastDraw = 0
+}
+
+func statusGlyph(s Status) string {
+	switch s {
+	case StatusRunning:
+		return "▶"
+	case StatusDone:
+		return "✅"
+	case StatusFailed:
+		return "🚨"
+	case StatusSkipped:
+		return "⚠️ "
+	default:
+		return "•"
+	}
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,127 @@
+// Package scanjobs dispatches bruh scan's individual tool runners through a
+// worker pool bounded by a configurable job count, replacing the old
+// one-spinner-per-tool sequential model with a live multi-line progress
+// view that scales across a polyglot repo's many scanners.
+package scanjobs
+
+import (
+	"context"
+	"sync"
+)
+
+// Status is a Scanner's lifecycle state, as tracked by the live progress
+// view.
+type Status int
+
+const (
+	StatusPending Status = iota
+	StatusRunning
+	StatusDone
+	StatusFailed
+	StatusSkipped
+)
+
+// Result is what a Scanner's Run returns. Message is the human-readable
+// summary to print once the scanner finishes - the tool's own output on
+// failure, or a short success line - and Err is non-nil when the scanner
+// found issues or couldn't run at all.
+type Result struct {
+	Name    string
+	Message string
+	Err     error
+}
+
+// Scanner is one tool bruh can run as part of `bruh scan` (gosec,
+// staticcheck, eslint, ...).
+type Scanner interface {
+	// Name identifies the scanner in the progress view and results.
+	Name() string
+	// Available reports whether the scanner's backing tool is installed.
+	// Unavailable scanners are skipped rather than counted as failures.
+	Available() bool
+	// Run executes the scanner, honoring ctx cancellation by tearing down
+	// any child process it spawned.
+	Run(ctx context.Context) Result
+}
+
+// Pool runs Scanners concurrently, bounded by Jobs, while driving a live
+// multi-line progress view.
+type Pool struct {
+	Jobs int
+}
+
+// NewPool returns a Pool bounded to jobs concurrent scanners. jobs <= 0
+// falls back to 1.
+func NewPool(jobs int) *Pool {
+	if jobs <= 0 {
+		jobs = 1
+	}
+	return &Pool{Jobs: jobs}
+}
+
+// Run executes every available scanner in scanners, skipping the rest,
+// and returns one Result per scanner that actually ran. onDone, if
+// non-nil, is invoked exactly once per finished scanner - serialized, so
+// it's safe to print from - as soon as that scanner's Run returns.
+//
+// If ctx is canceled (e.g. on Ctrl-C) before a scanner starts, that
+// scanner is skipped; scanners already running receive the cancellation
+// via ctx itself and are expected to tear down their child process.
+func (p *Pool) Run(ctx context.Context, scanners []Scanner, onDone func(Result)) []Result {
+	view := newProgressView()
+	defer view.stop()
+
+	var (
+		resultsMu sync.Mutex
+		printMu   sync.Mutex
+		results   []Result
+		wg        sync.WaitGroup
+	)
+
+	sem := make(chan struct{}, p.Jobs)
+
+	for _, s := range scanners {
+		if !s.Available() {
+			view.skip(s.Name())
+			continue
+		}
+
+		s := s
+		view.start(s.Name())
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				view.finish(s.Name(), StatusSkipped)
+				return
+			}
+			defer func() { <-sem }()
+
+			view.running(s.Name())
+			result := s.Run(ctx)
+
+			status := StatusDone
+			if result.Err != nil {
+				status = StatusFailed
+			}
+			view.finish(s.Name(), status)
+
+			if onDone != nil {
+				printMu.Lock()
+				onDone(result)
+				printMu.Unlock()
+			}
+
+			resultsMu.Lock()
+			results = append(results, result)
+			resultsMu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+	return results
+}
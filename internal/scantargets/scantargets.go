@@ -0,0 +1,178 @@
+// Package scantargets resolves the Go-style scan-target patterns bruh
+// scan accepts as positional args (e.g. "./cmd/...", "./pkg/foo",
+// "!./vendor/...") into the concrete directory lists each backing tool's
+// own invocation actually wants, similar in spirit to how `go build`
+// expands "..." patterns into package paths.
+package scantargets
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// skipDirs are never walked into when expanding a "..." pattern,
+// mirroring cmd's own hasFilesWithExtension walk.
+var skipDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"target":       true,
+}
+
+// Set is a resolved collection of scan targets, ready to hand to any
+// backing tool.
+type Set struct {
+	// Dirs is every directory the scan should cover, after expanding
+	// "..." patterns and subtracting anything excluded.
+	Dirs []string
+	// GoPackages is Dirs rewritten into Go package-path form (e.g.
+	// "./cmd/foo") for tools that take package patterns directly (gosec,
+	// staticcheck, go vet, govulncheck).
+	GoPackages []string
+}
+
+// Default is the Set used when the caller passes no scan-target patterns
+// and no excludes: everything, nothing excluded.
+func Default() Set {
+	return Set{Dirs: []string{"."}, GoPackages: []string{"./..."}}
+}
+
+// Resolve expands patterns (positional scan-target args) plus exclude
+// (additional exclusion patterns, e.g. from config.Config's Scan.Exclude)
+// into a Set. A pattern prefixed with "!" excludes anything it matches
+// instead of including it; exclude is treated the same way without
+// needing the prefix. If patterns is empty, the whole tree ("./...") is
+// the starting point before exclusions are applied.
+func Resolve(patterns []string, exclude []string) (Set, error) {
+	if len(patterns) == 0 && len(exclude) == 0 {
+		return Default(), nil
+	}
+
+	var include, excludePatterns []string
+	for _, p := range patterns {
+		if strings.HasPrefix(p, "!") {
+			excludePatterns = append(excludePatterns, strings.TrimPrefix(p, "!"))
+		} else if p != "" {
+			include = append(include, p)
+		}
+	}
+	excludePatterns = append(excludePatterns, exclude...)
+
+	if len(include) == 0 {
+		include = []string{"./..."}
+	}
+
+	includeDirs, err := expandAll(include)
+	if err != nil {
+		return Set{}, err
+	}
+	excludeDirs, err := expandAll(excludePatterns)
+	if err != nil {
+		return Set{}, err
+	}
+
+	excluded := map[string]bool{}
+	for _, d := range excludeDirs {
+		excluded[d] = true
+	}
+
+	var dirs []string
+	for _, d := range includeDirs {
+		if !isExcluded(d, excluded) {
+			dirs = append(dirs, d)
+		}
+	}
+
+	return Set{Dirs: dirs, GoPackages: goPackages(dirs)}, nil
+}
+
+// isExcluded reports whether dir is excluded, either directly or because
+// one of its ancestors is.
+func isExcluded(dir string, excluded map[string]bool) bool {
+	for d := dir; ; d = filepath.Dir(d) {
+		if excluded[d] {
+			return true
+		}
+		if d == "." || d == string(filepath.Separator) || d == filepath.Dir(d) {
+			return false
+		}
+	}
+}
+
+// expandAll expands every pattern in patterns into its concrete,
+// existing directories, deduplicated and in first-seen order.
+func expandAll(patterns []string) ([]string, error) {
+	seen := map[string]bool{}
+	var dirs []string
+	for _, p := range patterns {
+		expanded, err := expand(p)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range expanded {
+			if !seen[d] {
+				seen[d] = true
+				dirs = append(dirs, d)
+			}
+		}
+	}
+	return dirs, nil
+}
+
+// expand resolves a single Go-style pattern into its concrete
+// directories: "./cmd/..." walks the tree rooted at "./cmd" and collects
+// every directory in it (recursively), while "./pkg/foo" resolves to
+// just itself.
+func expand(pattern string) ([]string, error) {
+	clean := filepath.ToSlash(pattern)
+	if !strings.HasSuffix(clean, "/...") && clean != "..." {
+		dir := filepath.Clean(pattern)
+		if _, err := os.Stat(dir); err != nil {
+			return nil, err
+		}
+		return []string{dir}, nil
+	}
+
+	prefix := strings.TrimSuffix(strings.TrimSuffix(clean, "..."), "/")
+	if prefix == "" {
+		prefix = "."
+	}
+	root := filepath.Clean(prefix)
+
+	var dirs []string
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && (strings.HasPrefix(d.Name(), ".") || skipDirs[d.Name()]) {
+			return filepath.SkipDir
+		}
+		dirs = append(dirs, path)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dirs, nil
+}
+
+// goPackages rewrites dirs into the form Go tools expect as package
+// arguments: each directory becomes its own non-recursive package
+// pattern rather than collapsing back to "./...", since dirs is already
+// the fully expanded, exclusion-filtered set - re-expanding the root to
+// "./..." here would silently undo any "!pattern" or config.Scan.Exclude
+// exclusion.
+func goPackages(dirs []string) []string {
+	pkgs := make([]string, 0, len(dirs))
+	for _, d := range dirs {
+		p := filepath.ToSlash(d)
+		if p != "." && !strings.HasPrefix(p, "./") && !strings.HasPrefix(p, "/") {
+			p = "./" + p
+		}
+		pkgs = append(pkgs, p)
+	}
+	return pkgs
+}
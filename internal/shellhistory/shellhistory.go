@@ -0,0 +1,177 @@
+// Package shellhistory reads and parses shell history files for zsh, bash,
+// and fish, so commands like `bruh addcheat` can pull the last command run
+// regardless of which shell the user runs.
+package shellhistory
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Entry is a single history entry. When is the zero time if the history
+// format or line didn't record a timestamp.
+type Entry struct {
+	Command string
+	When    time.Time
+}
+
+// Detect returns the shell name ("zsh", "bash", or "fish") from $SHELL, or
+// "" if it's unset or not one of those three.
+func Detect() string {
+	switch base := filepath.Base(os.Getenv("SHELL")); base {
+	case "zsh", "bash", "fish":
+		return base
+	default:
+		return ""
+	}
+}
+
+// HistFile returns the history file path for shell, honoring $HISTFILE when
+// set and falling back to each shell's conventional default location.
+func HistFile(shell string) string {
+	if hist := os.Getenv("HISTFILE"); hist != "" {
+		return hist
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+
+	switch shell {
+	case "bash":
+		return filepath.Join(home, ".bash_history")
+	case "fish":
+		return filepath.Join(home, ".local", "share", "fish", "fish_history")
+	default:
+		return filepath.Join(home, ".zsh_history")
+	}
+}
+
+// Read reads and parses the history file for shell, returning entries in
+// oldest-to-newest order.
+func Read(shell string) ([]Entry, error) {
+	path := HistFile(shell)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history file %s: %v", path, err)
+	}
+
+	switch shell {
+	case "bash":
+		return parseBash(string(data)), nil
+	case "fish":
+		return parseFish(string(data)), nil
+	default:
+		return parseZsh(string(data)), nil
+	}
+}
+
+// Nth returns the nth-from-last entry (n=1 is the most recent), skipping
+// any command matched by exclude, which may be nil.
+func Nth(entries []Entry, n int, exclude *regexp.Regexp) (Entry, error) {
+	if n < 1 {
+		return Entry{}, fmt.Errorf("nth must be >= 1, got %d", n)
+	}
+
+	matched := 0
+	for i := len(entries) - 1; i >= 0; i-- {
+		if exclude != nil && exclude.MatchString(entries[i].Command) {
+			continue
+		}
+		matched++
+		if matched == n {
+			return entries[i], nil
+		}
+	}
+
+	return Entry{}, fmt.Errorf("history has fewer than %d matching entries", n)
+}
+
+// parseBash parses bash's plain history format, including the optional
+// "#<unix timestamp>" comment line HISTTIMEFORMAT writes before each command.
+func parseBash(data string) []Entry {
+	var entries []Entry
+	var pendingWhen time.Time
+
+	for _, line := range strings.Split(data, "\n") {
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "#") {
+			if ts, err := strconv.ParseInt(strings.TrimPrefix(line, "#"), 10, 64); err == nil {
+				pendingWhen = time.Unix(ts, 0)
+			}
+			continue
+		}
+
+		entries = append(entries, Entry{Command: line, When: pendingWhen})
+		pendingWhen = time.Time{}
+	}
+
+	return entries
+}
+
+// zshExtendedLine matches zsh's extended-history format:
+// ": <start>:<elapsed>;<command>".
+var zshExtendedLine = regexp.MustCompile(`^: (\d+):(\d+);(.*)$`)
+
+// parseZsh parses both zsh's plain history format and its extended format,
+// joining "\"-terminated line continuations into a single multiline command.
+func parseZsh(data string) []Entry {
+	var entries []Entry
+	lines := strings.Split(data, "\n")
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if line == "" {
+			continue
+		}
+
+		var when time.Time
+		cmd := line
+		if m := zshExtendedLine.FindStringSubmatch(line); m != nil {
+			if ts, err := strconv.ParseInt(m[1], 10, 64); err == nil {
+				when = time.Unix(ts, 0)
+			}
+			cmd = m[3]
+		}
+
+		for strings.HasSuffix(cmd, `\`) && i+1 < len(lines) {
+			i++
+			cmd = strings.TrimSuffix(cmd, `\`) + "\n" + lines[i]
+		}
+
+		entries = append(entries, Entry{Command: cmd, When: when})
+	}
+
+	return entries
+}
+
+// parseFish parses fish's YAML-ish history format:
+//
+//	- cmd: echo hi
+//	  when: 1700000000
+func parseFish(data string) []Entry {
+	var entries []Entry
+
+	for _, line := range strings.Split(data, "\n") {
+		switch {
+		case strings.HasPrefix(line, "- cmd: "):
+			entries = append(entries, Entry{Command: strings.TrimPrefix(line, "- cmd: ")})
+		case strings.HasPrefix(line, "  when: ") && len(entries) > 0:
+			if ts, err := strconv.ParseInt(strings.TrimPrefix(line, "  when: "), 10, 64); err == nil {
+				entries[len(entries)-1].When = time.Unix(ts, 0)
+			}
+		}
+	}
+
+	return entries
+}
@@ -0,0 +1,85 @@
+package shellhistory
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestParseBash_HandlesHISTTIMEFORMATComments(t *testing.T) {
+	data := "#1700000000\ngit status\nls -la\n"
+
+	entries := parseBash(data)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+
+	if got, want := entries[0].Command, "git status"; got != want {
+		t.Errorf("entries[0].Command = %q, want %q", got, want)
+	}
+	if !entries[0].When.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("entries[0].When = %v, want timestamp from the preceding comment", entries[0].When)
+	}
+	if !entries[1].When.IsZero() {
+		t.Errorf("entries[1].When = %v, want zero (no preceding comment)", entries[1].When)
+	}
+}
+
+func TestParseZsh_ExtendedFormatAndContinuations(t *testing.T) {
+	data := ": 1700000000:0;git status\n: 1700000001:0;echo foo \\\nbar\n"
+
+	entries := parseZsh(data)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+
+	if got, want := entries[0].Command, "git status"; got != want {
+		t.Errorf("entries[0].Command = %q, want %q", got, want)
+	}
+	if got, want := entries[1].Command, "echo foo \nbar"; got != want {
+		t.Errorf("entries[1].Command = %q, want %q", got, want)
+	}
+}
+
+func TestParseFish_CmdAndWhen(t *testing.T) {
+	data := "- cmd: echo hi\n  when: 1700000000\n- cmd: ls -la\n  when: 1700000001\n"
+
+	entries := parseFish(data)
+	want := []Entry{
+		{Command: "echo hi", When: time.Unix(1700000000, 0)},
+		{Command: "ls -la", When: time.Unix(1700000001, 0)},
+	}
+
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("parseFish() = %+v, want %+v", entries, want)
+	}
+}
+
+func TestNth_SkipsExcludedCommands(t *testing.T) {
+	entries := []Entry{
+		{Command: "git status"},
+		{Command: "ls"},
+		{Command: "git commit"},
+	}
+
+	got, err := Nth(entries, 1, regexp.MustCompile(`^ls$`))
+	if err != nil {
+		t.Fatalf("Nth returned error: %v", err)
+	}
+	if got.Command != "git commit" {
+		t.Errorf("Nth(1) = %q, want %q", got.Command, "git commit")
+	}
+
+	got, err = Nth(entries, 2, regexp.MustCompile(`^ls$`))
+	if err != nil {
+		t.Fatalf("Nth returned error: %v", err)
+	}
+	if got.Command != "git status" {
+		t.Errorf("Nth(2) = %q, want %q", got.Command, "git status")
+	}
+
+	if _, err := Nth(entries, 3, regexp.MustCompile(`^ls$`)); err == nil {
+		t.Error("expected an error when fewer than n entries match")
+	}
+}
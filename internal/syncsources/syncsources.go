@@ -0,0 +1,106 @@
+// Package syncsources tracks the remote navi cheat sheet repositories
+// `bruh sync` has been told to follow, persisted as YAML so the file stays
+// easy to hand-edit.
+package syncsources
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/MaiMarincic/bruh/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Source is one remote cheat sheet repository bruh keeps in sync.
+type Source struct {
+	// Name identifies the source; it's also the directory name under
+	// <cheat-dir>/.sources and the namespace prefix used on tag collisions.
+	Name string `yaml:"name"`
+	URL  string `yaml:"url"`
+	// Subdir restricts merging to this subdirectory of the cloned repo,
+	// if set.
+	Subdir string `yaml:"subdir,omitempty"`
+	// Strategy is the conflict resolution to use when this source defines
+	// a tag set that collides with an existing one: "prefer-local",
+	// "prefer-remote", or "namespace" (the default).
+	Strategy string `yaml:"strategy,omitempty"`
+}
+
+// State is the on-disk set of configured sources, persisted under
+// ~/.config/bruh/sources.yaml.
+type State struct {
+	path    string
+	Sources []Source `yaml:"sources"`
+}
+
+// Load reads the sources file, returning an empty State if it doesn't
+// exist yet.
+func Load() (*State, error) {
+	path := filepath.Join(config.ConfigDir(), "sources.yaml")
+
+	s := &State{path: path}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	s.path = path
+
+	return s, nil
+}
+
+// Get returns the source registered under name, if any.
+func (s *State) Get(name string) (Source, bool) {
+	for _, src := range s.Sources {
+		if src.Name == name {
+			return src, true
+		}
+	}
+	return Source{}, false
+}
+
+// Add registers src, replacing any existing source with the same name, and
+// persists the change.
+func (s *State) Add(src Source) error {
+	for i, existing := range s.Sources {
+		if existing.Name == src.Name {
+			s.Sources[i] = src
+			return s.save()
+		}
+	}
+	s.Sources = append(s.Sources, src)
+	return s.save()
+}
+
+// Remove drops the source named name and persists the change. It reports
+// whether a source was actually removed.
+func (s *State) Remove(name string) (bool, error) {
+	for i, src := range s.Sources {
+		if src.Name == name {
+			s.Sources = append(s.Sources[:i], s.Sources[i+1:]...)
+			return true, s.save()
+		}
+	}
+	return false, nil
+}
+
+func (s *State) save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, data, 0o644)
+}
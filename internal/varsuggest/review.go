@@ -0,0 +1,48 @@
+package varsuggest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Review walks the user through each suggestion via in/out, letting them
+// accept it as-is, rename its variable, or skip it entirely. If in hits EOF
+// (no interactive input available, e.g. stdin isn't a TTY), the remaining
+// suggestions are accepted as-is rather than blocking.
+func Review(suggestions []Suggestion, in *bufio.Reader, out io.Writer) []Suggestion {
+	var accepted []Suggestion
+
+	for _, s := range suggestions {
+		fmt.Fprintf(out, "Found <%s> for %q", s.Name, s.Original)
+		if s.Variable.SourceCommand != "" {
+			fmt.Fprintf(out, " (suggestions: %s)", s.Variable.SourceCommand)
+		}
+		fmt.Fprintln(out)
+		fmt.Fprint(out, "[a]ccept, [e]dit name, [s]kip? (default: accept) ")
+
+		line, err := in.ReadString('\n')
+		if err != nil {
+			accepted = append(accepted, s)
+			continue
+		}
+
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "s", "skip":
+			continue
+		case "e", "edit":
+			fmt.Fprintf(out, "New variable name for <%s>: ", s.Name)
+			newName, err := in.ReadString('\n')
+			if newName = strings.TrimSpace(newName); err == nil && newName != "" {
+				s.Name = newName
+				s.Variable.Name = newName
+			}
+			accepted = append(accepted, s)
+		default:
+			accepted = append(accepted, s)
+		}
+	}
+
+	return accepted
+}
@@ -0,0 +1,46 @@
+package varsuggest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tokenize splits a shell command into arguments, honoring single- and
+// double-quoted substrings so a quoted path or message isn't split on
+// embedded spaces.
+func tokenize(command string) ([]string, error) {
+	var tokens []string
+	var cur strings.Builder
+	var quote rune
+
+	flush := func() {
+		if cur.Len() > 0 {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+		}
+	}
+
+	for _, r := range command {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == ' ' || r == '\t' || r == '\n':
+			flush()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in %q", command)
+	}
+	flush()
+
+	return tokens, nil
+}
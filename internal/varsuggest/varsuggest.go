@@ -0,0 +1,200 @@
+// Package varsuggest heuristically detects which arguments of a captured
+// shell command are good candidates for navi <variable> placeholders, so
+// `bruh addcheat` can propose parameterized cheats instead of literal
+// one-shot commands.
+package varsuggest
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/MaiMarincic/bruh/pkg/cheatsheet"
+)
+
+// Suggestion proposes replacing the Original argument in a captured command
+// with a navi <Name> placeholder backed by Variable.
+type Suggestion struct {
+	Name     string
+	Original string
+	Variable cheatsheet.Variable
+}
+
+// Analyze tokenizes command and proposes variable substitutions for
+// arguments that look like file paths, git refs, docker IDs, container
+// names, k8s resources, integers/ports, or URLs. Suggestions are returned
+// in the order their variable first appears; an argument repeated later in
+// the command marks that variable's Options.Multi/Expand instead of
+// producing a second suggestion.
+func Analyze(command string) []Suggestion {
+	tokens, err := tokenize(command)
+	if err != nil || len(tokens) < 2 {
+		return nil
+	}
+
+	var suggestions []Suggestion
+	indexByName := map[string]int{}
+
+	for i := 1; i < len(tokens); i++ {
+		t := tokens[i]
+		if isFlag(t) {
+			continue
+		}
+
+		name, v, ok := classify(tokens, i)
+		if !ok {
+			continue
+		}
+
+		if existing, seen := indexByName[name]; seen {
+			suggestions[existing].Variable.Options.Multi = true
+			suggestions[existing].Variable.Options.Expand = true
+			continue
+		}
+
+		indexByName[name] = len(suggestions)
+		suggestions = append(suggestions, Suggestion{Name: name, Original: t, Variable: v})
+	}
+
+	return suggestions
+}
+
+// Apply replaces each accepted suggestion's Original token in command with
+// its <Name> placeholder.
+func Apply(command string, suggestions []Suggestion) string {
+	if len(suggestions) == 0 {
+		return command
+	}
+
+	replacements := make(map[string]string, len(suggestions))
+	for _, s := range suggestions {
+		replacements[s.Original] = "<" + s.Name + ">"
+	}
+
+	tokens, err := tokenize(command)
+	if err != nil {
+		return command
+	}
+
+	for i, t := range tokens {
+		if r, ok := replacements[t]; ok {
+			tokens[i] = r
+		}
+	}
+
+	return strings.Join(tokens, " ")
+}
+
+func isFlag(t string) bool {
+	return strings.HasPrefix(t, "-")
+}
+
+func isInt(t string) bool {
+	_, err := strconv.Atoi(t)
+	return err == nil
+}
+
+var urlSchemeRe = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*://`)
+
+func isURL(t string) bool {
+	return urlSchemeRe.MatchString(t)
+}
+
+func isPath(t string) bool {
+	return strings.HasPrefix(t, "/") || strings.HasPrefix(t, "./") ||
+		strings.HasPrefix(t, "../") || strings.HasPrefix(t, "~/") ||
+		strings.Contains(t, "/")
+}
+
+var gitRefSubcommands = map[string]string{
+	"checkout": "branch",
+	"switch":   "branch",
+	"merge":    "branch",
+	"rebase":   "branch",
+	"branch":   "branch",
+}
+
+var dockerIDSubcommands = map[string]bool{
+	"start": true, "stop": true, "restart": true, "kill": true,
+	"rm": true, "exec": true, "logs": true, "inspect": true, "attach": true,
+}
+
+var dockerImageSubcommands = map[string]bool{
+	"rmi": true, "run": true, "tag": true, "push": true, "pull": true, "save": true,
+}
+
+var kubectlVerbs = map[string]bool{
+	"get": true, "describe": true, "delete": true, "logs": true, "exec": true, "edit": true,
+}
+
+// classify proposes a variable for tokens[i], given the full token list for
+// context (program name, subcommand, preceding argument).
+func classify(tokens []string, i int) (string, cheatsheet.Variable, bool) {
+	t := tokens[i]
+	program := tokens[0]
+	var subcommand string
+	if len(tokens) > 1 {
+		subcommand = tokens[1]
+	}
+
+	switch program {
+	case "git":
+		if ref, ok := gitRefSubcommands[subcommand]; ok && i >= 2 {
+			return ref, cheatsheet.Variable{
+				Name:          ref,
+				SourceCommand: "git branch | awk '{print $NF}'",
+			}, true
+		}
+		if subcommand == "tag" && i >= 2 {
+			return "tag", cheatsheet.Variable{Name: "tag", SourceCommand: "git tag"}, true
+		}
+		if (subcommand == "push" || subcommand == "pull" || subcommand == "fetch") && i == 2 {
+			return "remote", cheatsheet.Variable{Name: "remote", SourceCommand: "git remote"}, true
+		}
+
+	case "docker":
+		if dockerIDSubcommands[subcommand] && i >= 2 {
+			return "container", cheatsheet.Variable{
+				Name:          "container",
+				SourceCommand: "docker ps -a",
+				Options:       cheatsheet.VariableOptions{Column: 1, HeaderLines: 1},
+			}, true
+		}
+		if dockerImageSubcommands[subcommand] && i >= 2 {
+			return "image", cheatsheet.Variable{
+				Name:          "image",
+				SourceCommand: "docker images",
+				Options:       cheatsheet.VariableOptions{Column: 3, HeaderLines: 1},
+			}, true
+		}
+
+	case "kubectl", "k":
+		if kubectlVerbs[subcommand] && i == 2 {
+			resource := t
+			return "resource", cheatsheet.Variable{
+				Name:          "resource",
+				SourceCommand: "kubectl get " + resource + " -o name",
+			}, true
+		}
+		if kubectlVerbs[subcommand] && i == 3 {
+			resourceType := tokens[2]
+			name := strings.TrimSuffix(resourceType, "s")
+			return name, cheatsheet.Variable{
+				Name:          name,
+				SourceCommand: "kubectl get " + resourceType + " -o name",
+			}, true
+		}
+	}
+
+	if isURL(t) {
+		return "url", cheatsheet.Variable{Name: "url"}, true
+	}
+	if isPath(t) {
+		return "path", cheatsheet.Variable{Name: "path", SourceCommand: "find . -type f"}, true
+	}
+	if isInt(t) {
+		return "port", cheatsheet.Variable{Name: "port"}, true
+	}
+
+	return "", cheatsheet.Variable{}, false
+}
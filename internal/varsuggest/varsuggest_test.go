@@ -0,0 +1,51 @@
+package varsuggest
+
+import "testing"
+
+func TestAnalyze_GitCheckout(t *testing.T) {
+	suggestions := Analyze("git checkout main")
+	if len(suggestions) != 1 {
+		t.Fatalf("expected 1 suggestion, got %d: %+v", len(suggestions), suggestions)
+	}
+	if got, want := suggestions[0].Name, "branch"; got != want {
+		t.Errorf("Name = %q, want %q", got, want)
+	}
+	if got, want := suggestions[0].Variable.SourceCommand, "git branch | awk '{print $NF}'"; got != want {
+		t.Errorf("SourceCommand = %q, want %q", got, want)
+	}
+}
+
+func TestAnalyze_DockerContainer(t *testing.T) {
+	suggestions := Analyze("docker logs my-container")
+	if len(suggestions) != 1 || suggestions[0].Name != "container" {
+		t.Fatalf("expected a single container suggestion, got %+v", suggestions)
+	}
+	if suggestions[0].Variable.Options.HeaderLines != 1 {
+		t.Errorf("expected HeaderLines 1, got %d", suggestions[0].Variable.Options.HeaderLines)
+	}
+}
+
+func TestAnalyze_RepeatedArgumentMarksMulti(t *testing.T) {
+	suggestions := Analyze("cat ./a.json ./a.json")
+	if len(suggestions) != 1 {
+		t.Fatalf("expected 1 suggestion (deduped), got %d: %+v", len(suggestions), suggestions)
+	}
+	if !suggestions[0].Variable.Options.Multi || !suggestions[0].Variable.Options.Expand {
+		t.Errorf("expected Multi and Expand to be set for a repeated argument, got %+v", suggestions[0].Variable.Options)
+	}
+}
+
+func TestAnalyze_SkipsFlags(t *testing.T) {
+	suggestions := Analyze("ls -la /tmp")
+	if len(suggestions) != 1 || suggestions[0].Original != "/tmp" {
+		t.Fatalf("expected only the path argument to be suggested, got %+v", suggestions)
+	}
+}
+
+func TestApply_ReplacesOnlyGivenSuggestions(t *testing.T) {
+	suggestions := []Suggestion{{Name: "branch", Original: "main"}}
+	got := Apply("git checkout main", suggestions)
+	if want := "git checkout <branch>"; got != want {
+		t.Errorf("Apply() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,93 @@
+// Package wtstate tracks which worktrees were created by bruh, so `bruh
+// worktree prune` can tell them apart from worktrees a user made by hand.
+package wtstate
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/MaiMarincic/bruh/config"
+)
+
+// Entry records one worktree bruh created.
+type Entry struct {
+	Path   string `json:"path"`
+	Branch string `json:"branch"`
+}
+
+// Store is the on-disk set of worktrees bruh created, persisted under
+// ~/.config/bruh/worktrees.json.
+type Store struct {
+	path    string
+	Entries []Entry `json:"entries"`
+}
+
+// Load reads the state file, returning an empty Store if it doesn't exist
+// yet.
+func Load() (*Store, error) {
+	path := filepath.Join(config.ConfigDir(), "worktrees.json")
+
+	s := &Store{path: path}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	s.path = path
+
+	return s, nil
+}
+
+// Add records a worktree as bruh-created and persists the store.
+func (s *Store) Add(path, branch string) error {
+	for _, e := range s.Entries {
+		if e.Path == path {
+			return nil
+		}
+	}
+	s.Entries = append(s.Entries, Entry{Path: path, Branch: branch})
+	return s.save()
+}
+
+// Remove drops a worktree from the store and persists the change.
+func (s *Store) Remove(path string) error {
+	entries := s.Entries[:0]
+	for _, e := range s.Entries {
+		if e.Path != path {
+			entries = append(entries, e)
+		}
+	}
+	s.Entries = entries
+	return s.save()
+}
+
+// Contains reports whether bruh created the worktree at path.
+func (s *Store) Contains(path string) bool {
+	for _, e := range s.Entries {
+		if e.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Store) save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, data, 0o644)
+}
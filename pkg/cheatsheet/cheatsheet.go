@@ -0,0 +1,160 @@
+// Package cheatsheet parses and emits navi cheat sheets (.cheat / .cheat.md
+// files) natively, without shelling out to an LLM for every edit. See
+// https://github.com/denisidoro/navi for the format this implements.
+package cheatsheet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// VariableOptions captures the "--- --flag value ..." suffix of a navi
+// pre-defined variable line.
+type VariableOptions struct {
+	Column        int
+	Map           string
+	PreventExtra  bool
+	FzfOverrides  string
+	Expand        bool
+	Multi         bool
+	HeaderLines   int
+	Delimiter     string
+	Query         string
+	Filter        string
+	Header        string
+	Preview       string
+	PreviewWindow string
+}
+
+// Variable is a navi pre-defined variable: a "$ name: command --- options"
+// line that supplies suggestions for <name> placeholders in a command.
+type Variable struct {
+	Name          string
+	SourceCommand string
+	Options       VariableOptions
+}
+
+// Entry is a single navi cheat: a tagged, described command plus the
+// pre-defined variables it uses.
+type Entry struct {
+	Tags []string
+
+	// Comments holds metacomment ("; ...") lines preceding the entry. Navi
+	// ignores these; they exist for editors.
+	Comments []string
+
+	// Extends holds the tags named by an "@ ..." extension line for this
+	// entry's tag block, if any.
+	Extends []string
+
+	Description string
+
+	// Command may be multiline (a navi "snippet").
+	Command string
+
+	Variables []Variable
+}
+
+// Sheet is a single parsed .cheat / .cheat.md file.
+type Sheet struct {
+	Path    string
+	Entries []Entry
+}
+
+// New returns an empty sheet that will be written to path on Save.
+func New(path string) *Sheet {
+	return &Sheet{Path: path}
+}
+
+// AddEntry appends entry to the sheet. Save must be called afterward to
+// persist the change.
+func (s *Sheet) AddEntry(entry Entry) {
+	s.Entries = append(s.Entries, entry)
+}
+
+// Save writes the sheet back to its Path, in navi cheat sheet syntax.
+func (s *Sheet) Save() error {
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cheat directory: %v", err)
+	}
+	if err := os.WriteFile(s.Path, []byte(s.render()), 0o644); err != nil {
+		return fmt.Errorf("failed to write cheat sheet %s: %v", s.Path, err)
+	}
+	return nil
+}
+
+// Load parses every .cheat / .cheat.md file directly inside dir.
+func Load(dir string) ([]*Sheet, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cheat directory: %v", err)
+	}
+
+	var sheets []*Sheet
+	for _, f := range files {
+		if f.IsDir() || !isCheatFile(f.Name()) {
+			continue
+		}
+
+		path := filepath.Join(dir, f.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", path, err)
+		}
+
+		entries, err := parse(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+		}
+
+		sheets = append(sheets, &Sheet{Path: path, Entries: entries})
+	}
+
+	return sheets, nil
+}
+
+func isCheatFile(name string) bool {
+	return strings.HasSuffix(name, ".cheat.md") || strings.HasSuffix(name, ".cheat")
+}
+
+// PickSheet returns whichever of sheets shares the most tags with wantTags,
+// so a new entry can be placed deterministically without an LLM. Returns
+// nil if sheets is empty.
+func PickSheet(sheets []*Sheet, wantTags []string) *Sheet {
+	if len(sheets) == 0 {
+		return nil
+	}
+
+	want := make(map[string]bool, len(wantTags))
+	for _, t := range wantTags {
+		want[normalizeTag(t)] = true
+	}
+
+	best := sheets[0]
+	bestScore := -1
+	for _, sheet := range sheets {
+		score := 0
+		counted := make(map[string]bool)
+		for _, e := range sheet.Entries {
+			for _, t := range e.Tags {
+				t = normalizeTag(t)
+				if want[t] && !counted[t] {
+					counted[t] = true
+					score++
+				}
+			}
+		}
+		if score > bestScore {
+			bestScore = score
+			best = sheet
+		}
+	}
+
+	return best
+}
+
+func normalizeTag(t string) string {
+	return strings.ToLower(strings.TrimSpace(t))
+}
@@ -0,0 +1,120 @@
+package cheatsheet
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const sampleCheat = `% git, code
+
+# Change branch
+git checkout <branch>
+
+$ branch: git branch | awk '{print $NF}'
+
+; Useful for cleaning merged branches
+# Delete a local branch
+git branch -D <branch>
+`
+
+func TestParseAndRender_RoundTrips(t *testing.T) {
+	entries, err := parse(sampleCheat)
+	if err != nil {
+		t.Fatalf("parse returned error: %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+
+	first := entries[0]
+	if got, want := first.Description, "Change branch"; got != want {
+		t.Errorf("first.Description = %q, want %q", got, want)
+	}
+	if got, want := first.Command, "git checkout <branch>"; got != want {
+		t.Errorf("first.Command = %q, want %q", got, want)
+	}
+	if !reflect.DeepEqual(first.Tags, []string{"git", "code"}) {
+		t.Errorf("first.Tags = %v, want [git code]", first.Tags)
+	}
+	if len(first.Variables) != 1 || first.Variables[0].Name != "branch" {
+		t.Fatalf("expected a single %q variable, got %v", "branch", first.Variables)
+	}
+	if got, want := first.Variables[0].SourceCommand, "git branch | awk '{print $NF}'"; got != want {
+		t.Errorf("variable SourceCommand = %q, want %q", got, want)
+	}
+
+	second := entries[1]
+	if got, want := len(second.Comments), 1; got != want {
+		t.Fatalf("expected %d metacomment, got %d: %v", want, got, second.Comments)
+	}
+	if got, want := second.Comments[0], "Useful for cleaning merged branches"; got != want {
+		t.Errorf("second.Comments[0] = %q, want %q", got, want)
+	}
+
+	sheet := &Sheet{Path: filepath.Join(t.TempDir(), "git.cheat"), Entries: entries}
+	if err := sheet.Save(); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	reparsed, err := Load(filepath.Dir(sheet.Path))
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(reparsed) != 1 {
+		t.Fatalf("expected 1 sheet, got %d", len(reparsed))
+	}
+	if !reflect.DeepEqual(reparsed[0].Entries, entries) {
+		t.Errorf("round-tripped entries differ:\ngot:  %+v\nwant: %+v", reparsed[0].Entries, entries)
+	}
+}
+
+func TestParseVariableLine_Options(t *testing.T) {
+	v, err := parseVariableLine(`$ image_id: docker images --- --column 3 --header-lines 1 --delimiter '\s\s+'`)
+	if err != nil {
+		t.Fatalf("parseVariableLine returned error: %v", err)
+	}
+
+	want := Variable{
+		Name:          "image_id",
+		SourceCommand: "docker images",
+		Options: VariableOptions{
+			Column:      3,
+			HeaderLines: 1,
+			Delimiter:   `\s\s+`,
+		},
+	}
+	if !reflect.DeepEqual(v, want) {
+		t.Errorf("parseVariableLine() = %+v, want %+v", v, want)
+	}
+}
+
+func TestPickSheet(t *testing.T) {
+	gitSheet := &Sheet{Path: "git.cheat", Entries: []Entry{{Tags: []string{"git", "code"}}}}
+	dockerSheet := &Sheet{Path: "docker.cheat", Entries: []Entry{{Tags: []string{"docker"}}}}
+
+	got := PickSheet([]*Sheet{gitSheet, dockerSheet}, []string{"git"})
+	if got != gitSheet {
+		t.Errorf("PickSheet() = %v, want gitSheet", got.Path)
+	}
+}
+
+func TestLoad_FiltersNonCheatFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "git.cheat"), []byte(sampleCheat), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a cheat sheet"), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	sheets, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(sheets) != 1 {
+		t.Fatalf("expected 1 sheet, got %d", len(sheets))
+	}
+}
@@ -0,0 +1,265 @@
+package cheatsheet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parse turns the raw contents of a .cheat / .cheat.md file into entries,
+// in file order.
+func parse(data string) ([]Entry, error) {
+	var entries []Entry
+
+	var tags []string
+	var extends []string
+	var pendingComments []string
+	idx := -1 // index into entries of the block currently being built, -1 if none
+
+	var inFence bool
+	var fenceLines []string
+
+	appendCommand := func(line string) {
+		if entries[idx].Command == "" {
+			entries[idx].Command = line
+		} else {
+			entries[idx].Command += "\n" + line
+		}
+	}
+
+	startEntry := func() {
+		entries = append(entries, Entry{
+			Tags:     append([]string{}, tags...),
+			Comments: pendingComments,
+			Extends:  extends,
+		})
+		idx = len(entries) - 1
+		pendingComments = nil
+	}
+
+	for _, raw := range strings.Split(data, "\n") {
+		trimmed := strings.TrimSpace(raw)
+
+		if inFence {
+			if trimmed == "```" {
+				inFence = false
+				if idx == -1 {
+					startEntry()
+				}
+				appendCommand(strings.Join(fenceLines, "\n"))
+				fenceLines = nil
+				continue
+			}
+			fenceLines = append(fenceLines, raw)
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			continue
+
+		case strings.HasPrefix(trimmed, "%"):
+			tags = splitTags(trimmed[1:])
+			extends = nil
+			idx = -1
+
+		case strings.HasPrefix(trimmed, "@"):
+			extends = append(extends, splitTags(trimmed[1:])...)
+
+		case strings.HasPrefix(trimmed, ";"):
+			pendingComments = append(pendingComments, strings.TrimSpace(strings.TrimPrefix(trimmed, ";")))
+
+		case strings.HasPrefix(trimmed, "#"):
+			desc := strings.TrimSpace(strings.TrimPrefix(trimmed, "#"))
+			if idx == -1 || entries[idx].Command != "" {
+				startEntry()
+			}
+			if entries[idx].Description == "" {
+				entries[idx].Description = desc
+			} else {
+				entries[idx].Description += "\n" + desc
+			}
+
+		case strings.HasPrefix(trimmed, "```"):
+			inFence = true
+			fenceLines = nil
+
+		case strings.HasPrefix(trimmed, "$"):
+			v, err := parseVariableLine(trimmed)
+			if err != nil {
+				return nil, err
+			}
+			if idx == -1 {
+				return nil, fmt.Errorf("variable %q has no preceding entry", v.Name)
+			}
+			entries[idx].Variables = append(entries[idx].Variables, v)
+
+		default:
+			if idx == -1 {
+				startEntry()
+			}
+			appendCommand(raw)
+		}
+	}
+
+	return entries, nil
+}
+
+func splitTags(s string) []string {
+	parts := strings.Split(s, ",")
+	tags := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			tags = append(tags, p)
+		}
+	}
+	return tags
+}
+
+func parseVariableLine(trimmed string) (Variable, error) {
+	body := strings.TrimSpace(strings.TrimPrefix(trimmed, "$"))
+
+	colon := strings.Index(body, ":")
+	if colon == -1 {
+		return Variable{}, fmt.Errorf("malformed variable line: %q", trimmed)
+	}
+
+	name := strings.TrimSpace(body[:colon])
+	rest := strings.TrimSpace(body[colon+1:])
+
+	sourceCommand := rest
+	var optsText string
+	if i := strings.Index(rest, "---"); i != -1 {
+		sourceCommand = strings.TrimSpace(rest[:i])
+		optsText = strings.TrimSpace(rest[i+len("---"):])
+	}
+
+	opts, err := parseVariableOptions(optsText)
+	if err != nil {
+		return Variable{}, fmt.Errorf("malformed options for variable %q: %v", name, err)
+	}
+
+	return Variable{Name: name, SourceCommand: sourceCommand, Options: opts}, nil
+}
+
+func parseVariableOptions(text string) (VariableOptions, error) {
+	var opts VariableOptions
+	if text == "" {
+		return opts, nil
+	}
+
+	tokens, err := tokenize(text)
+	if err != nil {
+		return opts, err
+	}
+
+	next := func(i int) (string, int, error) {
+		if i+1 >= len(tokens) {
+			return "", i, fmt.Errorf("%q requires a value", tokens[i])
+		}
+		return tokens[i+1], i + 1, nil
+	}
+
+	var val string
+
+	for i := 0; i < len(tokens); i++ {
+		switch tokens[i] {
+		case "--column":
+			if val, i, err = next(i); err != nil {
+				return opts, err
+			}
+			if opts.Column, err = strconv.Atoi(val); err != nil {
+				return opts, fmt.Errorf("invalid --column value %q: %v", val, err)
+			}
+		case "--map":
+			if opts.Map, i, err = next(i); err != nil {
+				return opts, err
+			}
+		case "--prevent-extra":
+			opts.PreventExtra = true
+		case "--fzf-overrides":
+			if opts.FzfOverrides, i, err = next(i); err != nil {
+				return opts, err
+			}
+		case "--expand":
+			opts.Expand = true
+		case "--multi":
+			opts.Multi = true
+		case "--header-lines":
+			if val, i, err = next(i); err != nil {
+				return opts, err
+			}
+			if opts.HeaderLines, err = strconv.Atoi(val); err != nil {
+				return opts, fmt.Errorf("invalid --header-lines value %q: %v", val, err)
+			}
+		case "--delimiter":
+			if opts.Delimiter, i, err = next(i); err != nil {
+				return opts, err
+			}
+		case "--query":
+			if opts.Query, i, err = next(i); err != nil {
+				return opts, err
+			}
+		case "--filter":
+			if opts.Filter, i, err = next(i); err != nil {
+				return opts, err
+			}
+		case "--header":
+			if opts.Header, i, err = next(i); err != nil {
+				return opts, err
+			}
+		case "--preview":
+			if opts.Preview, i, err = next(i); err != nil {
+				return opts, err
+			}
+		case "--preview-window":
+			if opts.PreviewWindow, i, err = next(i); err != nil {
+				return opts, err
+			}
+		default:
+			return opts, fmt.Errorf("unknown variable option %q", tokens[i])
+		}
+	}
+
+	return opts, nil
+}
+
+// tokenize splits s on whitespace, honoring single- and double-quoted
+// substrings the way navi's own variable options are written in examples
+// (e.g. --delimiter '\s\s+').
+func tokenize(s string) ([]string, error) {
+	var tokens []string
+	var cur strings.Builder
+	var quote rune
+
+	flush := func() {
+		if cur.Len() > 0 {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+		}
+	}
+
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == ' ' || r == '\t':
+			flush()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in %q", s)
+	}
+	flush()
+
+	return tokens, nil
+}
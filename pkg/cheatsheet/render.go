@@ -0,0 +1,126 @@
+package cheatsheet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// render serializes a sheet's entries back to navi cheat sheet syntax,
+// preserving entry order and emitting a new "% tags" header only when the
+// tags change from the previous entry so consecutive entries sharing tags
+// stay grouped the way they would in a hand-written file.
+func (s *Sheet) render() string {
+	var b strings.Builder
+	var prevTags []string
+	wroteAny := false
+
+	for _, e := range s.Entries {
+		if wroteAny {
+			b.WriteString("\n")
+		}
+		wroteAny = true
+
+		if !sameTags(e.Tags, prevTags) {
+			b.WriteString("% " + strings.Join(e.Tags, ", ") + "\n")
+			if len(e.Extends) > 0 {
+				b.WriteString("@ " + strings.Join(e.Extends, ", ") + "\n")
+			}
+			b.WriteString("\n")
+			prevTags = e.Tags
+		}
+
+		for _, c := range e.Comments {
+			b.WriteString("; " + c + "\n")
+		}
+
+		if e.Description != "" {
+			for _, line := range strings.Split(e.Description, "\n") {
+				b.WriteString("# " + line + "\n")
+			}
+		}
+
+		if e.Command != "" {
+			b.WriteString(e.Command + "\n")
+		}
+
+		for _, v := range e.Variables {
+			b.WriteString("\n" + renderVariable(v) + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+func renderVariable(v Variable) string {
+	line := fmt.Sprintf("$ %s: %s", v.Name, v.SourceCommand)
+	if opts := renderVariableOptions(v.Options); opts != "" {
+		line += " --- " + opts
+	}
+	return line
+}
+
+func renderVariableOptions(o VariableOptions) string {
+	var parts []string
+
+	if o.Column != 0 {
+		parts = append(parts, "--column "+strconv.Itoa(o.Column))
+	}
+	if o.Map != "" {
+		parts = append(parts, "--map "+quoteIfNeeded(o.Map))
+	}
+	if o.PreventExtra {
+		parts = append(parts, "--prevent-extra")
+	}
+	if o.FzfOverrides != "" {
+		parts = append(parts, "--fzf-overrides "+quoteIfNeeded(o.FzfOverrides))
+	}
+	if o.Expand {
+		parts = append(parts, "--expand")
+	}
+	if o.Multi {
+		parts = append(parts, "--multi")
+	}
+	if o.HeaderLines != 0 {
+		parts = append(parts, "--header-lines "+strconv.Itoa(o.HeaderLines))
+	}
+	if o.Delimiter != "" {
+		parts = append(parts, "--delimiter "+quoteIfNeeded(o.Delimiter))
+	}
+	if o.Query != "" {
+		parts = append(parts, "--query "+quoteIfNeeded(o.Query))
+	}
+	if o.Filter != "" {
+		parts = append(parts, "--filter "+quoteIfNeeded(o.Filter))
+	}
+	if o.Header != "" {
+		parts = append(parts, "--header "+quoteIfNeeded(o.Header))
+	}
+	if o.Preview != "" {
+		parts = append(parts, "--preview "+quoteIfNeeded(o.Preview))
+	}
+	if o.PreviewWindow != "" {
+		parts = append(parts, "--preview-window "+quoteIfNeeded(o.PreviewWindow))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func quoteIfNeeded(s string) string {
+	if strings.ContainsAny(s, " \t") {
+		return "'" + s + "'"
+	}
+	return s
+}
+
+func sameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
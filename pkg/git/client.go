@@ -0,0 +1,209 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/MaiMarincic/bruh/internal/gitcmd"
+)
+
+// RealClient runs git as a subprocess via gitcmd.
+type RealClient struct{}
+
+// NewClient returns a Client backed by the system git binary.
+func NewClient() *RealClient {
+	return &RealClient{}
+}
+
+func (c *RealClient) CurrentBranch() (string, error) {
+	stdout, _, err := gitcmd.New(context.Background(), "branch").
+		AddArguments("--show-current").
+		RunStdString(nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get current branch: %v", err)
+	}
+	return strings.TrimSpace(stdout), nil
+}
+
+func (c *RealClient) RepoRoot() (string, error) {
+	stdout, _, err := gitcmd.New(context.Background(), "rev-parse").
+		AddArguments("--show-toplevel").
+		RunStdString(nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get repository root: %v", err)
+	}
+	return strings.TrimSpace(stdout), nil
+}
+
+func (c *RealClient) RepoName() (string, error) {
+	root, err := c.RepoRoot()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Base(root), nil
+}
+
+func (c *RealClient) IsRepo() bool {
+	_, _, err := gitcmd.New(context.Background(), "rev-parse").
+		AddArguments("--git-dir").
+		RunStdString(nil)
+	return err == nil
+}
+
+func (c *RealClient) HasStagedChanges() bool {
+	_, _, err := gitcmd.New(context.Background(), "diff").
+		AddArguments("--cached", "--exit-code").
+		RunStdString(nil)
+	return err != nil
+}
+
+func (c *RealClient) DefaultBranch() (string, error) {
+	stdout, _, err := gitcmd.New(context.Background(), "rev-parse").
+		AddArguments("--abbrev-ref", "origin/HEAD").
+		RunStdString(nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to determine default branch: %v", err)
+	}
+	return strings.TrimPrefix(strings.TrimSpace(stdout), "origin/"), nil
+}
+
+func (c *RealClient) AddWorktree(path, newBranch, from string) error {
+	_, err := gitcmd.New(context.Background(), "worktree").
+		AddArguments("add").
+		AddDynamicArguments(path).
+		AddOptionValues("-b", newBranch).
+		AddDynamicArguments(from).
+		CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to create worktree: %v", err)
+	}
+	return nil
+}
+
+func (c *RealClient) RemoveWorktree(path string, force bool) error {
+	cmd := gitcmd.New(context.Background(), "worktree").AddArguments("remove")
+	if force {
+		cmd = cmd.AddArguments("--force")
+	}
+	if _, err := cmd.AddDynamicArguments(path).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to remove worktree: %v", err)
+	}
+	return nil
+}
+
+func (c *RealClient) ListWorktrees() ([]Worktree, error) {
+	stdout, _, err := gitcmd.New(context.Background(), "worktree").
+		AddArguments("list", "--porcelain").
+		RunStdString(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worktrees: %v", err)
+	}
+	return parseWorktreePorcelain(stdout), nil
+}
+
+func parseWorktreePorcelain(output string) []Worktree {
+	var worktrees []Worktree
+	var current Worktree
+
+	flush := func() {
+		if current.Path != "" {
+			worktrees = append(worktrees, current)
+		}
+		current = Worktree{}
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case strings.HasPrefix(line, "worktree "):
+			flush()
+			current.Path = strings.TrimPrefix(line, "worktree ")
+		case strings.HasPrefix(line, "HEAD "):
+			current.Head = strings.TrimPrefix(line, "HEAD ")
+		case strings.HasPrefix(line, "branch "):
+			current.Branch = strings.TrimPrefix(strings.TrimPrefix(line, "branch "), "refs/heads/")
+		}
+	}
+	flush()
+
+	return worktrees
+}
+
+func (c *RealClient) IsBranchMerged(branch, base string) (bool, error) {
+	_, _, err := gitcmd.New(context.Background(), "merge-base").
+		AddArguments("--is-ancestor").
+		AddDynamicArguments(branch, base).
+		RunStdString(nil)
+	if err == nil {
+		return true, nil
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to check if %s is merged into %s: %v", branch, base, err)
+}
+
+func (c *RealClient) RemoteBranchExists(branch string) (bool, error) {
+	stdout, _, err := gitcmd.New(context.Background(), "ls-remote").
+		AddArguments("--heads", "origin").
+		AddDynamicArguments(branch).
+		RunStdString(nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to check remote branch %s: %v", branch, err)
+	}
+	return strings.TrimSpace(stdout) != "", nil
+}
+
+func (c *RealClient) DiffNameStatus(base, head string) (string, error) {
+	stdout, _, err := gitcmd.New(context.Background(), "diff").
+		AddDynamicArguments(fmt.Sprintf("%s...%s", base, head)).
+		AddArguments("--name-status").
+		RunStdString(nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff: %v", err)
+	}
+	return stdout, nil
+}
+
+func (c *RealClient) LogOneLine(base, head string) (string, error) {
+	stdout, _, err := gitcmd.New(context.Background(), "log").
+		AddDynamicArguments(fmt.Sprintf("%s..%s", base, head)).
+		AddArguments("--oneline").
+		RunStdString(nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit log: %v", err)
+	}
+	return stdout, nil
+}
+
+func (c *RealClient) HasUpstream(branch string) (bool, error) {
+	stdout, _, err := gitcmd.New(context.Background(), "config").
+		AddArguments("--get").
+		AddDynamicArguments(fmt.Sprintf("branch.%s.remote", branch)).
+		RunStdString(nil)
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check upstream for %s: %v", branch, err)
+	}
+	return strings.TrimSpace(stdout) != "", nil
+}
+
+// Commit passes message to git verbatim over stdin via "-F -" rather than
+// "-m", since a message is a value and not a flag-position argument: a
+// multi-line AI-generated message (subject + blank line + body) is valid
+// input for git but would be rejected by AddDynamicArguments' newline check.
+func (c *RealClient) Commit(message string, noVerify bool) error {
+	cmd := gitcmd.New(context.Background(), "commit").AddArguments("-F", "-")
+	if noVerify {
+		cmd = cmd.AddArguments("--no-verify")
+	}
+	stdout, stderr, err := cmd.RunStdString(&gitcmd.RunOpts{Stdin: strings.NewReader(message)})
+	if err != nil {
+		return fmt.Errorf("git commit failed: %s", stdout+stderr)
+	}
+	return nil
+}
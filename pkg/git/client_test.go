@@ -0,0 +1,30 @@
+package git
+
+import "testing"
+
+func TestParseWorktreePorcelain(t *testing.T) {
+	output := `worktree /repo
+HEAD abc1234
+branch refs/heads/main
+
+worktree /repo-feature-worktree
+HEAD def5678
+branch refs/heads/feature-worktree
+`
+
+	got := parseWorktreePorcelain(output)
+
+	want := []Worktree{
+		{Path: "/repo", Branch: "main", Head: "abc1234"},
+		{Path: "/repo-feature-worktree", Branch: "feature-worktree", Head: "def5678"},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d worktrees, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("worktree %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
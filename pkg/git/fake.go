@@ -0,0 +1,149 @@
+package git
+
+// FakeClient is a Client implementation for tests. Each ...Fn field is an
+// optional hook for the method of the same name; when a hook is nil, the
+// method returns a fixed default (e.g. IsRepo returns true, DefaultBranch
+// returns "main").
+type FakeClient struct {
+	CurrentBranchFn      func() (string, error)
+	RepoRootFn           func() (string, error)
+	RepoNameFn           func() (string, error)
+	IsRepoFn             func() bool
+	HasStagedFn          func() bool
+	DefaultBranchFn      func() (string, error)
+	AddWorktreeFn        func(path, newBranch, from string) error
+	ListWorktreesFn      func() ([]Worktree, error)
+	RemoveWorktreeFn     func(path string, force bool) error
+	IsBranchMergedFn     func(branch, base string) (bool, error)
+	RemoteBranchExistsFn func(branch string) (bool, error)
+	HasUpstreamFn        func(branch string) (bool, error)
+	DiffNameStatusFn     func(base, head string) (string, error)
+	LogOneLineFn         func(base, head string) (string, error)
+	CommitFn             func(message string, noVerify bool) error
+
+	// Calls records every method invoked, in order, for assertions.
+	Calls []string
+}
+
+func (f *FakeClient) CurrentBranch() (string, error) {
+	f.Calls = append(f.Calls, "CurrentBranch")
+	if f.CurrentBranchFn != nil {
+		return f.CurrentBranchFn()
+	}
+	return "", nil
+}
+
+func (f *FakeClient) RepoRoot() (string, error) {
+	f.Calls = append(f.Calls, "RepoRoot")
+	if f.RepoRootFn != nil {
+		return f.RepoRootFn()
+	}
+	return "", nil
+}
+
+func (f *FakeClient) RepoName() (string, error) {
+	f.Calls = append(f.Calls, "RepoName")
+	if f.RepoNameFn != nil {
+		return f.RepoNameFn()
+	}
+	return "", nil
+}
+
+func (f *FakeClient) IsRepo() bool {
+	f.Calls = append(f.Calls, "IsRepo")
+	if f.IsRepoFn != nil {
+		return f.IsRepoFn()
+	}
+	return true
+}
+
+func (f *FakeClient) HasStagedChanges() bool {
+	f.Calls = append(f.Calls, "HasStagedChanges")
+	if f.HasStagedFn != nil {
+		return f.HasStagedFn()
+	}
+	return false
+}
+
+func (f *FakeClient) DefaultBranch() (string, error) {
+	f.Calls = append(f.Calls, "DefaultBranch")
+	if f.DefaultBranchFn != nil {
+		return f.DefaultBranchFn()
+	}
+	return "main", nil
+}
+
+func (f *FakeClient) AddWorktree(path, newBranch, from string) error {
+	f.Calls = append(f.Calls, "AddWorktree")
+	if f.AddWorktreeFn != nil {
+		return f.AddWorktreeFn(path, newBranch, from)
+	}
+	return nil
+}
+
+func (f *FakeClient) ListWorktrees() ([]Worktree, error) {
+	f.Calls = append(f.Calls, "ListWorktrees")
+	if f.ListWorktreesFn != nil {
+		return f.ListWorktreesFn()
+	}
+	return nil, nil
+}
+
+func (f *FakeClient) RemoveWorktree(path string, force bool) error {
+	f.Calls = append(f.Calls, "RemoveWorktree")
+	if f.RemoveWorktreeFn != nil {
+		return f.RemoveWorktreeFn(path, force)
+	}
+	return nil
+}
+
+func (f *FakeClient) IsBranchMerged(branch, base string) (bool, error) {
+	f.Calls = append(f.Calls, "IsBranchMerged")
+	if f.IsBranchMergedFn != nil {
+		return f.IsBranchMergedFn(branch, base)
+	}
+	return false, nil
+}
+
+func (f *FakeClient) RemoteBranchExists(branch string) (bool, error) {
+	f.Calls = append(f.Calls, "RemoteBranchExists")
+	if f.RemoteBranchExistsFn != nil {
+		return f.RemoteBranchExistsFn(branch)
+	}
+	return true, nil
+}
+
+func (f *FakeClient) HasUpstream(branch string) (bool, error) {
+	f.Calls = append(f.Calls, "HasUpstream")
+	if f.HasUpstreamFn != nil {
+		return f.HasUpstreamFn(branch)
+	}
+	return true, nil
+}
+
+func (f *FakeClient) DiffNameStatus(base, head string) (string, error) {
+	f.Calls = append(f.Calls, "DiffNameStatus")
+	if f.DiffNameStatusFn != nil {
+		return f.DiffNameStatusFn(base, head)
+	}
+	return "", nil
+}
+
+func (f *FakeClient) LogOneLine(base, head string) (string, error) {
+	f.Calls = append(f.Calls, "LogOneLine")
+	if f.LogOneLineFn != nil {
+		return f.LogOneLineFn(base, head)
+	}
+	return "", nil
+}
+
+func (f *FakeClient) Commit(message string, noVerify bool) error {
+	f.Calls = append(f.Calls, "Commit")
+	if f.CommitFn != nil {
+		return f.CommitFn(message, noVerify)
+	}
+	return nil
+}
+
+var _ Client = (*FakeClient)(nil)
+var _ Client = (*RealClient)(nil)
@@ -0,0 +1,31 @@
+// Package git wraps the git invocations bruh's commands need behind a small
+// Client interface, so cmd/*.go depends on git operations rather than on
+// os/exec directly. This lets runE handlers be tested against a FakeClient
+// without spawning a real git process.
+package git
+
+// Worktree describes one entry from `git worktree list --porcelain`.
+type Worktree struct {
+	Path   string
+	Branch string
+	Head   string
+}
+
+// Client is the set of git operations bruh's commands need.
+type Client interface {
+	CurrentBranch() (string, error)
+	RepoRoot() (string, error)
+	RepoName() (string, error)
+	IsRepo() bool
+	HasStagedChanges() bool
+	DefaultBranch() (string, error)
+	AddWorktree(path, newBranch, from string) error
+	ListWorktrees() ([]Worktree, error)
+	RemoveWorktree(path string, force bool) error
+	IsBranchMerged(branch, base string) (bool, error)
+	RemoteBranchExists(branch string) (bool, error)
+	HasUpstream(branch string) (bool, error)
+	DiffNameStatus(base, head string) (string, error)
+	LogOneLine(base, head string) (string, error)
+	Commit(message string, noVerify bool) error
+}
@@ -0,0 +1,90 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+)
+
+// Baseline is the set of finding fingerprints a team has already accepted
+// - the "wall of pre-existing issues" a legacy codebase starts with -
+// so that `bruh scan --baseline` only fails on findings that weren't
+// already known about.
+type Baseline struct {
+	Fingerprints []string `json:"fingerprints"`
+}
+
+// NewBaseline builds a Baseline from every unique finding fingerprint in
+// r.
+func NewBaseline(r Report) Baseline {
+	seen := map[string]bool{}
+	var b Baseline
+	for _, f := range r.Findings {
+		fp := f.Fingerprint()
+		if seen[fp] {
+			continue
+		}
+		seen[fp] = true
+		b.Fingerprints = append(b.Fingerprints, fp)
+	}
+	sort.Strings(b.Fingerprints)
+	return b
+}
+
+// LoadBaseline reads a Baseline previously written by Save.
+func LoadBaseline(path string) (Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Baseline{}, err
+	}
+
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return Baseline{}, err
+	}
+	return b, nil
+}
+
+// Save writes b to path as indented JSON.
+func (b Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Intersect returns the fingerprints b shares with other, preserving
+// sorted order. It's how --update-baseline drops stale entries (fixed
+// findings) without re-admitting anything new.
+func (b Baseline) Intersect(other Baseline) Baseline {
+	present := map[string]bool{}
+	for _, fp := range other.Fingerprints {
+		present[fp] = true
+	}
+
+	var out Baseline
+	for _, fp := range b.Fingerprints {
+		if present[fp] {
+			out.Fingerprints = append(out.Fingerprints, fp)
+		}
+	}
+	return out
+}
+
+// NewFindings returns the subset of r's findings whose fingerprint isn't
+// in baseline.
+func (r Report) NewFindings(baseline Baseline) Report {
+	known := map[string]bool{}
+	for _, fp := range baseline.Fingerprints {
+		known[fp] = true
+	}
+
+	var out Report
+	for _, f := range r.Findings {
+		if !known[f.Fingerprint()] {
+			out.Findings = append(out.Findings, f)
+		}
+	}
+	return out
+}
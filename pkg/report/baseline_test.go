@@ -0,0 +1,70 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFingerprint_SurvivesLineShift(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.go")
+
+	if err := os.WriteFile(path, []byte("package main\n\nfunc bad() {}\n"), 0o644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	before := Finding{Tool: "gosec", RuleID: "G104", File: path, Line: 3}.Fingerprint()
+
+	if err := os.WriteFile(path, []byte("package main\n\n// a comment shifted the line below down\nfunc bad() {}\n"), 0o644); err != nil {
+		t.Fatalf("failed to rewrite temp file: %v", err)
+	}
+	after := Finding{Tool: "gosec", RuleID: "G104", File: path, Line: 4}.Fingerprint()
+
+	if before != after {
+		t.Error("expected fingerprint to survive an edit that only shifts the line number")
+	}
+}
+
+func TestReport_NewFindings_FiltersKnownFingerprints(t *testing.T) {
+	r := Report{Findings: []Finding{
+		{Tool: "gosec", RuleID: "G104", Message: "unhandled error"},
+		{Tool: "bandit", RuleID: "B101", Message: "assert used"},
+	}}
+
+	baseline := NewBaseline(Report{Findings: r.Findings[:1]})
+
+	fresh := r.NewFindings(baseline)
+	if len(fresh.Findings) != 1 || fresh.Findings[0].Tool != "bandit" {
+		t.Errorf("NewFindings() = %+v, want just the bandit finding", fresh.Findings)
+	}
+}
+
+func TestBaseline_SaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+
+	want := NewBaseline(Report{Findings: []Finding{
+		{Tool: "gosec", RuleID: "G104", Message: "unhandled error"},
+	}})
+
+	if err := want.Save(path); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	got, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatalf("LoadBaseline() error: %v", err)
+	}
+	if len(got.Fingerprints) != 1 || got.Fingerprints[0] != want.Fingerprints[0] {
+		t.Errorf("LoadBaseline() = %+v, want %+v", got, want)
+	}
+}
+
+func TestBaseline_Intersect_DropsStaleEntries(t *testing.T) {
+	old := Baseline{Fingerprints: []string{"a", "b", "c"}}
+	current := Baseline{Fingerprints: []string{"b", "c", "d"}}
+
+	updated := old.Intersect(current)
+	if len(updated.Fingerprints) != 2 || updated.Fingerprints[0] != "b" || updated.Fingerprints[1] != "c" {
+		t.Errorf("Intersect() = %+v, want [b c]", updated.Fingerprints)
+	}
+}
@@ -0,0 +1,47 @@
+package report
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"strings"
+)
+
+// Fingerprint returns a stable identifier for f, built from the tool,
+// rule, file, and the trimmed text of the reported line itself rather
+// than its line number - so a baseline entry survives unrelated edits
+// that shift line numbers elsewhere in the file. If the file can't be
+// read (deleted, not a real path, no Line recorded), the fingerprint
+// falls back to just tool+rule+file.
+func (f Finding) Fingerprint() string {
+	h := sha256.New()
+	h.Write([]byte(f.Tool))
+	h.Write([]byte{0})
+	h.Write([]byte(f.RuleID))
+	h.Write([]byte{0})
+	h.Write([]byte(f.File))
+	h.Write([]byte{0})
+	h.Write([]byte(lineContext(f.File, f.Line)))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func lineContext(file string, line int) string {
+	if file == "" || line <= 0 {
+		return ""
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		if n == line {
+			return strings.TrimSpace(scanner.Text())
+		}
+	}
+	return ""
+}
@@ -0,0 +1,58 @@
+// Package report defines the normalized shape `bruh scan` aggregates every
+// backing tool's findings into, and serializes that aggregate as SARIF or
+// JSON so results can be piped into CI dashboards or GitHub code scanning
+// uploads instead of scraped from colored text.
+package report
+
+import (
+	"encoding/json"
+)
+
+// Severity is a normalized finding severity, independent of whatever scale
+// the originating tool uses.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Finding is one normalized issue reported by a scan tool.
+type Finding struct {
+	// Tool is the name of the backing tool that reported this finding,
+	// e.g. "gosec" or "npm audit".
+	Tool string `json:"tool"`
+	// RuleID is the tool's own identifier for the rule/check that fired,
+	// e.g. "G104" for gosec or "CVE-2023-1234" for an audit tool.
+	RuleID   string   `json:"rule_id"`
+	Severity Severity `json:"severity"`
+	File     string   `json:"file,omitempty"`
+	Line     int      `json:"line,omitempty"`
+	Message  string   `json:"message"`
+	// CWE is the Common Weakness Enumeration ID, e.g. "CWE-89", when the
+	// tool provides one.
+	CWE string `json:"cwe,omitempty"`
+}
+
+// Report is the full set of findings collected across every scanner that
+// ran.
+type Report struct {
+	Findings []Finding `json:"findings"`
+}
+
+// HasErrors reports whether any finding was at error severity.
+func (r Report) HasErrors() bool {
+	for _, f := range r.Findings {
+		if f.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// ToJSON serializes the report in bruh's own minimal JSON shape; see
+// ToSARIF for the SARIF 2.1.0 equivalent.
+func (r Report) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
@@ -0,0 +1,60 @@
+package report
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestReport_HasErrors(t *testing.T) {
+	r := Report{Findings: []Finding{{Severity: SeverityWarning}}}
+	if r.HasErrors() {
+		t.Error("expected no errors among warning-only findings")
+	}
+
+	r.Findings = append(r.Findings, Finding{Severity: SeverityError})
+	if !r.HasErrors() {
+		t.Error("expected HasErrors to be true once an error finding is present")
+	}
+}
+
+func TestToSARIF_GroupsResultsByTool(t *testing.T) {
+	r := Report{Findings: []Finding{
+		{Tool: "gosec", RuleID: "G104", Severity: SeverityWarning, File: "main.go", Line: 10, Message: "unhandled error"},
+		{Tool: "bandit", RuleID: "B101", Severity: SeverityError, File: "app.py", Line: 3, Message: "assert used"},
+	}}
+
+	data, err := r.ToSARIF()
+	if err != nil {
+		t.Fatalf("ToSARIF() error: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("ToSARIF() did not produce valid JSON: %v", err)
+	}
+
+	if len(log.Runs) != 2 {
+		t.Fatalf("expected 2 runs (one per tool), got %d", len(log.Runs))
+	}
+	if !strings.Contains(string(data), "G104") || !strings.Contains(string(data), "B101") {
+		t.Error("expected both rule IDs to appear in the SARIF output")
+	}
+}
+
+func TestToJSON_RoundTrips(t *testing.T) {
+	r := Report{Findings: []Finding{{Tool: "gitleaks", RuleID: "generic-api-key", Severity: SeverityError, Message: "possible secret"}}}
+
+	data, err := r.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON() error: %v", err)
+	}
+
+	var got Report
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("ToJSON() did not produce valid JSON: %v", err)
+	}
+	if len(got.Findings) != 1 || got.Findings[0].Tool != "gitleaks" {
+		t.Errorf("round-tripped report = %+v, want 1 gitleaks finding", got)
+	}
+}
@@ -0,0 +1,138 @@
+package report
+
+import "encoding/json"
+
+// sarifLog is a deliberately partial SARIF 2.1.0 document: just enough of
+// the schema (https://docs.oasis-open.org/sarif/sarif/v2.1.0) for GitHub
+// code scanning and similar CI consumers to ingest bruh's findings.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules,omitempty"`
+}
+
+type sarifRule struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// ToSARIF serializes the report as a SARIF 2.1.0 log with one run per tool
+// that contributed findings.
+func (r Report) ToSARIF() ([]byte, error) {
+	byTool := map[string][]Finding{}
+	var toolOrder []string
+	for _, f := range r.Findings {
+		if _, seen := byTool[f.Tool]; !seen {
+			toolOrder = append(toolOrder, f.Tool)
+		}
+		byTool[f.Tool] = append(byTool[f.Tool], f)
+	}
+
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+	}
+
+	for _, tool := range toolOrder {
+		log.Runs = append(log.Runs, sarifRun{
+			Tool:    sarifTool{Driver: sarifDriver{Name: tool, Rules: sarifRules(byTool[tool])}},
+			Results: sarifResults(byTool[tool]),
+		})
+	}
+
+	return json.MarshalIndent(log, "", "  ")
+}
+
+func sarifRules(findings []Finding) []sarifRule {
+	seen := map[string]bool{}
+	var rules []sarifRule
+	for _, f := range findings {
+		if f.RuleID == "" || seen[f.RuleID] {
+			continue
+		}
+		seen[f.RuleID] = true
+		rules = append(rules, sarifRule{ID: f.RuleID, Name: f.RuleID})
+	}
+	return rules
+}
+
+func sarifResults(findings []Finding) []sarifResult {
+	results := make([]sarifResult, len(findings))
+	for i, f := range findings {
+		result := sarifResult{
+			RuleID:  f.RuleID,
+			Level:   sarifLevel(f.Severity),
+			Message: sarifMessage{Text: f.Message},
+		}
+		if f.File != "" {
+			result.Locations = []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: f.File},
+					Region:           sarifRegionFor(f.Line),
+				},
+			}}
+		}
+		results[i] = result
+	}
+	return results
+}
+
+func sarifRegionFor(line int) *sarifRegion {
+	if line <= 0 {
+		return nil
+	}
+	return &sarifRegion{StartLine: line}
+}
+
+func sarifLevel(s Severity) string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}